@@ -0,0 +1,204 @@
+// Package bundler implements a client for the ERC-4337 bundler JSON-RPC API.
+package bundler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"io"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+)
+
+// Client talks to a single ERC-4337 bundler endpoint over HTTP.
+type Client struct {
+	url        string       // The bundler JSON-RPC endpoint
+	httpClient *http.Client // HTTP client used for every request
+	headers    http.Header  // Extra headers, e.g. Authorization, sent with every request
+	nextID     atomic.Uint64
+}
+
+// NewClient creates a bundler client for the given endpoint. A nil httpClient falls back
+// to http.DefaultClient, and headers are added to every request.
+func NewClient(url string, httpClient *http.Client, headers http.Header) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		url:        url,
+		httpClient: httpClient,
+		headers:    headers.Clone(),
+	}
+}
+
+// SendUserOperation submits a signed userop to the bundler's mempool and returns its userOpHash.
+func (c *Client) SendUserOperation(ctx context.Context, userOp any, entryPoint common.Address) (common.Hash, error) {
+	var hash common.Hash
+	if err := c.call(ctx, &hash, "eth_sendUserOperation", userOp, entryPoint); err != nil {
+		return common.Hash{}, err
+	}
+
+	return hash, nil
+}
+
+// EstimateUserOperationGas asks the bundler for the gas limits of a userop. The signature
+// only needs to have the right shape, it is not validated.
+func (c *Client) EstimateUserOperationGas(ctx context.Context, userOp any, entryPoint common.Address) (*GasEstimate, error) {
+	var estimate *GasEstimate
+	if err := c.call(ctx, &estimate, "eth_estimateUserOperationGas", userOp, entryPoint); err != nil {
+		return nil, err
+	}
+
+	if estimate == nil {
+		return nil, fmt.Errorf("bundler returned no gas estimate")
+	}
+
+	return estimate, nil
+}
+
+// GetUserOperationByHash returns the userop with the given hash, or nil if the bundler does not know it.
+func (c *Client) GetUserOperationByHash(ctx context.Context, userOpHash common.Hash) (*UserOperationByHash, error) {
+	var op *UserOperationByHash
+	if err := c.call(ctx, &op, "eth_getUserOperationByHash", userOpHash); err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// GetUserOperationReceipt returns the receipt of the userop with the given hash, or nil if
+// it has not been included yet.
+func (c *Client) GetUserOperationReceipt(ctx context.Context, userOpHash common.Hash) (*UserOperationReceipt, error) {
+	var receipt *UserOperationReceipt
+	if err := c.call(ctx, &receipt, "eth_getUserOperationReceipt", userOpHash); err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// SupportedEntryPoints returns the EntryPoint addresses the bundler accepts userops for.
+func (c *Client) SupportedEntryPoints(ctx context.Context) ([]common.Address, error) {
+	var entryPoints []common.Address
+	if err := c.call(ctx, &entryPoints, "eth_supportedEntryPoints"); err != nil {
+		return nil, err
+	}
+
+	return entryPoints, nil
+}
+
+// ChainID returns the chain ID the bundler operates on.
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	var chainID hexutil.Big
+	if err := c.call(ctx, &chainID, "eth_chainId"); err != nil {
+		return nil, err
+	}
+
+	return chainID.ToInt(), nil
+}
+
+// MaxPriorityFeePerGas returns the minimum priority fee the bundler accepts, using the
+// rundler_maxPriorityFeePerGas extension served by Alchemy's Rundler.
+func (c *Client) MaxPriorityFeePerGas(ctx context.Context) (*big.Int, error) {
+	var fee hexutil.Big
+	if err := c.call(ctx, &fee, "rundler_maxPriorityFeePerGas"); err != nil {
+		return nil, err
+	}
+
+	return fee.ToInt(), nil
+}
+
+// UserOperationGasPrice returns slow, standard and fast fee suggestions using the
+// pimlico_getUserOperationGasPrice extension served by Pimlico's Alto.
+func (c *Client) UserOperationGasPrice(ctx context.Context) (*UserOperationGasPrice, error) {
+	var price *UserOperationGasPrice
+	if err := c.call(ctx, &price, "pimlico_getUserOperationGasPrice"); err != nil {
+		return nil, err
+	}
+
+	if price == nil {
+		return nil, fmt.Errorf("bundler returned no gas price")
+	}
+
+	return price, nil
+}
+
+// IsMethodNotFound reports whether err is the JSON-RPC error for an unsupported method.
+func IsMethodNotFound(err error) bool {
+	var rpcErr *Error
+	return errors.As(err, &rpcErr) && rpcErr.Code == -32601
+}
+
+// call performs a single JSON-RPC request and decodes its result into result.
+// JSON-RPC errors are returned as *Error.
+func (c *Client) call(ctx context.Context, result any, method string, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+
+	body, err := json.Marshal(request{
+		Jsonrpc: "2.0",
+		Id:      c.nextID.Add(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("accept", "application/json")
+	req.Header.Set("content-type", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	payload, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	var rpcRes response
+	if err := json.Unmarshal(payload, &rpcRes); err != nil {
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return fmt.Errorf("%s: bundler responded with %s: %s", method, res.Status, bytes.TrimSpace(payload))
+		}
+		return fmt.Errorf("%s: invalid JSON-RPC response: %w", method, err)
+	}
+
+	if rpcRes.Error != nil {
+		return rpcRes.Error
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%s: bundler responded with %s", method, res.Status)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if len(rpcRes.Result) == 0 {
+		return fmt.Errorf("%s: response has neither result nor error", method)
+	}
+
+	return json.Unmarshal(rpcRes.Result, result)
+}
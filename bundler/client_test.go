@@ -0,0 +1,342 @@
+package bundler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var (
+	testEntryPoint = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	testHash       = common.HexToHash("0x3b7a1f9c5d2e4f6a8b0c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a")
+)
+
+// stubBundler is an httptest stand-in bundler answering each method with a canned raw
+// JSON-RPC response body. It records the last request it received.
+type stubBundler struct {
+	t         *testing.T
+	responses map[string]string // Response body by method, %d is replaced by the request id
+	status    int               // HTTP status to reply with, 200 when zero
+	last      request
+	header    http.Header
+}
+
+func (s *stubBundler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		request
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.t.Errorf("decoding request: %v", err)
+	}
+	s.last = req.request
+	s.last.Params = make([]any, len(req.Params))
+	for i, p := range req.Params {
+		s.last.Params[i] = string(p)
+	}
+	s.header = r.Header.Clone()
+
+	body, ok := s.responses[req.Method]
+	if !ok {
+		body = `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`
+	}
+
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+	}
+	_, _ = w.Write([]byte(body))
+}
+
+// newStubBundler starts a stand-in bundler and returns a client for it.
+func newStubBundler(t *testing.T, responses map[string]string) (*stubBundler, *Client) {
+	stub := &stubBundler{t: t, responses: responses}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	return stub, NewClient(server.URL, server.Client(), http.Header{"Authorization": {"Bearer secret"}})
+}
+
+func result(raw string) string {
+	return `{"jsonrpc":"2.0","id":1,"result":` + raw + `}`
+}
+
+func TestClientSendUserOperation(t *testing.T) {
+	stub, client := newStubBundler(t, map[string]string{
+		"eth_sendUserOperation": result(`"` + testHash.Hex() + `"`),
+	})
+
+	hash, err := client.SendUserOperation(context.Background(), map[string]string{"sender": "0x01"}, testEntryPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hash != testHash {
+		t.Errorf("hash = %s, want %s", hash, testHash)
+	}
+
+	if stub.last.Jsonrpc != "2.0" || stub.last.Method != "eth_sendUserOperation" || len(stub.last.Params) != 2 {
+		t.Errorf("unexpected request %+v", stub.last)
+	}
+	if stub.last.Params[1] != `"`+strings.ToLower(testEntryPoint.Hex())+`"` {
+		t.Errorf("entry point param = %v", stub.last.Params[1])
+	}
+	if got := stub.header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization header = %q", got)
+	}
+	if got := stub.header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type header = %q", got)
+	}
+}
+
+func TestClientEstimateUserOperationGas(t *testing.T) {
+	_, client := newStubBundler(t, map[string]string{
+		"eth_estimateUserOperationGas": result(`{"preVerificationGas":"0x5208","verificationGasLimit":"0x11170","callGasLimit":"0x88b8"}`),
+	})
+
+	estimate, err := client.EstimateUserOperationGas(context.Background(), struct{}{}, testEntryPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if estimate.PreVerificationGas.ToInt().Int64() != 21000 || estimate.VerificationGasLimit.ToInt().Int64() != 70000 || estimate.CallGasLimit.ToInt().Int64() != 35000 {
+		t.Errorf("unexpected estimate %+v", estimate)
+	}
+	if estimate.PaymasterVerificationGasLimit != nil {
+		t.Errorf("paymaster limit = %v, want nil", estimate.PaymasterVerificationGasLimit)
+	}
+}
+
+func TestClientEstimateUserOperationGasNull(t *testing.T) {
+	_, client := newStubBundler(t, map[string]string{
+		"eth_estimateUserOperationGas": result(`null`),
+	})
+
+	if _, err := client.EstimateUserOperationGas(context.Background(), struct{}{}, testEntryPoint); err == nil {
+		t.Fatal("expected an error for a null estimate")
+	}
+}
+
+func TestClientGetUserOperationByHash(t *testing.T) {
+	_, client := newStubBundler(t, map[string]string{
+		"eth_getUserOperationByHash": result(`{"userOperation":{"sender":"0x01"},"entryPoint":"` + testEntryPoint.Hex() + `","blockNumber":"0x10","blockHash":"` + testHash.Hex() + `","transactionHash":"` + testHash.Hex() + `"}`),
+	})
+
+	op, err := client.GetUserOperationByHash(context.Background(), testHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if op.EntryPoint != testEntryPoint || op.BlockNumber.ToInt().Int64() != 16 || *op.TransactionHash != testHash {
+		t.Errorf("unexpected op %+v", op)
+	}
+	if string(op.UserOperation) != `{"sender":"0x01"}` {
+		t.Errorf("userOperation = %s", op.UserOperation)
+	}
+}
+
+func TestClientGetUserOperationByHashUnknown(t *testing.T) {
+	_, client := newStubBundler(t, map[string]string{
+		"eth_getUserOperationByHash": result(`null`),
+	})
+
+	op, err := client.GetUserOperationByHash(context.Background(), testHash)
+	if err != nil || op != nil {
+		t.Fatalf("got %v, %v, want nil, nil", op, err)
+	}
+}
+
+func TestClientGetUserOperationReceipt(t *testing.T) {
+	_, client := newStubBundler(t, map[string]string{
+		"eth_getUserOperationReceipt": result(`{
+			"userOpHash":"` + testHash.Hex() + `",
+			"entryPoint":"` + testEntryPoint.Hex() + `",
+			"sender":"0x1306b01bC3e4AD202612D3843387e94737673F53",
+			"nonce":"0x1",
+			"paymaster":"0x0000000000000000000000000000000000000000",
+			"actualGasCost":"0x2386f26fc10000",
+			"actualGasUsed":"0x1d4c0",
+			"success":false,
+			"reason":"AA21 didn't pay prefund",
+			"logs":[],
+			"receipt":{"transactionHash":"` + testHash.Hex() + `","blockNumber":"0x20","status":"0x1","gasUsed":"0x30d40","logs":[]}
+		}`),
+	})
+
+	receipt, err := client.GetUserOperationReceipt(context.Background(), testHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if receipt.UserOpHash != testHash || receipt.Success || receipt.Reason != "AA21 didn't pay prefund" {
+		t.Errorf("unexpected receipt %+v", receipt)
+	}
+	if receipt.ActualGasCost.ToInt().Cmp(big.NewInt(10000000000000000)) != 0 {
+		t.Errorf("actualGasCost = %s", receipt.ActualGasCost)
+	}
+	if receipt.Receipt.TransactionHash != testHash || receipt.Receipt.Status != 1 || receipt.Receipt.BlockNumber.ToInt().Int64() != 32 {
+		t.Errorf("unexpected transaction receipt %+v", receipt.Receipt)
+	}
+}
+
+func TestClientGetUserOperationReceiptPending(t *testing.T) {
+	_, client := newStubBundler(t, map[string]string{
+		"eth_getUserOperationReceipt": result(`null`),
+	})
+
+	receipt, err := client.GetUserOperationReceipt(context.Background(), testHash)
+	if err != nil || receipt != nil {
+		t.Fatalf("got %v, %v, want nil, nil", receipt, err)
+	}
+}
+
+func TestClientSupportedEntryPoints(t *testing.T) {
+	stub, client := newStubBundler(t, map[string]string{
+		"eth_supportedEntryPoints": result(`["` + testEntryPoint.Hex() + `","0x0000000071727De22E5E9d8BAf0edAc6f37da032"]`),
+	})
+
+	entryPoints, err := client.SupportedEntryPoints(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entryPoints) != 2 || entryPoints[0] != testEntryPoint {
+		t.Errorf("entry points = %v", entryPoints)
+	}
+	if len(stub.last.Params) != 0 {
+		t.Errorf("params = %v, want an empty array", stub.last.Params)
+	}
+}
+
+func TestClientFeeMethods(t *testing.T) {
+	_, client := newStubBundler(t, map[string]string{
+		"eth_chainId":                      result(`"0xaa36a7"`),
+		"rundler_maxPriorityFeePerGas":     result(`"0x59682f00"`),
+		"pimlico_getUserOperationGasPrice": result(`{"slow":{"maxFeePerGas":"0x1","maxPriorityFeePerGas":"0x1"},"standard":{"maxFeePerGas":"0x2","maxPriorityFeePerGas":"0x2"},"fast":{"maxFeePerGas":"0x3","maxPriorityFeePerGas":"0x3"}}`),
+	})
+	ctx := context.Background()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chainID.Int64() != 11155111 {
+		t.Errorf("chain ID = %s", chainID)
+	}
+
+	fee, err := client.MaxPriorityFeePerGas(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee.Int64() != 1500000000 {
+		t.Errorf("priority fee = %s", fee)
+	}
+
+	price, err := client.UserOperationGasPrice(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Fast.MaxFeePerGas.ToInt().Int64() != 3 || price.Slow.MaxPriorityFeePerGas.ToInt().Int64() != 1 {
+		t.Errorf("unexpected gas price %+v", price)
+	}
+}
+
+func TestClientJSONRPCError(t *testing.T) {
+	_, client := newStubBundler(t, map[string]string{
+		"eth_sendUserOperation": `{"jsonrpc":"2.0","id":1,"error":{"code":-32500,"message":"AA21 didn't pay prefund","data":"0x220266b6"}}`,
+	})
+
+	_, err := client.SendUserOperation(context.Background(), struct{}{}, testEntryPoint)
+
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("error = %v, want *Error", err)
+	}
+	if rpcErr.Code != -32500 || rpcErr.Message != "AA21 didn't pay prefund" || string(rpcErr.Data) != `"0x220266b6"` {
+		t.Errorf("unexpected error %+v", rpcErr)
+	}
+	if IsMethodNotFound(err) {
+		t.Error("IsMethodNotFound = true for -32500")
+	}
+}
+
+func TestClientMethodNotFound(t *testing.T) {
+	_, client := newStubBundler(t, nil)
+
+	_, err := client.MaxPriorityFeePerGas(context.Background())
+	if !IsMethodNotFound(err) {
+		t.Errorf("IsMethodNotFound(%v) = false", err)
+	}
+}
+
+func TestClientHTTPErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		rpcErr  bool
+		message string
+	}{
+		{name: "non-JSON body", status: http.StatusBadGateway, body: "upstream unavailable", message: "502 Bad Gateway: upstream unavailable"},
+		{name: "JSON-RPC error", status: http.StatusTooManyRequests, body: `{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"rate limited"}}`, rpcErr: true},
+		{name: "JSON result", status: http.StatusInternalServerError, body: result(`"0x1"`), message: "500 Internal Server Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub, client := newStubBundler(t, map[string]string{"eth_chainId": tt.body})
+			stub.status = tt.status
+
+			_, err := client.ChainID(context.Background())
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			var rpcErr *Error
+			if errors.As(err, &rpcErr) != tt.rpcErr {
+				t.Errorf("error %v: *Error = %v, want %v", err, !tt.rpcErr, tt.rpcErr)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("error %q does not contain %q", err, tt.message)
+			}
+		})
+	}
+}
+
+func TestClientEmptyResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "neither result nor error", body: `{"jsonrpc":"2.0","id":1}`, want: "neither result nor error"},
+		{name: "invalid JSON", body: `{"jsonrpc":`, want: "invalid JSON-RPC response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client := newStubBundler(t, map[string]string{"eth_chainId": tt.body})
+
+			_, err := client.ChainID(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientContextCancelled(t *testing.T) {
+	_, client := newStubBundler(t, map[string]string{"eth_chainId": result(`"0x1"`)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.ChainID(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
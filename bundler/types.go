@@ -0,0 +1,102 @@
+package bundler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// request is a JSON-RPC 2.0 request envelope.
+type request struct {
+	Jsonrpc string `json:"jsonrpc"`
+	Id      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+// response is a JSON-RPC 2.0 response envelope.
+type response struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	Id      uint64          `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *Error          `json:"error"`
+}
+
+// Error is a JSON-RPC error object returned by the bundler.
+type Error struct {
+	Code    int             `json:"code"`    // The JSON-RPC or ERC-4337 error code, e.g. -32500 for a rejected op
+	Message string          `json:"message"` // Human readable description from the bundler
+	Data    json.RawMessage `json:"data"`    // Optional error specific payload
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if len(e.Data) > 0 && string(e.Data) != "null" {
+		return fmt.Sprintf("bundler error %d: %s (data: %s)", e.Code, e.Message, e.Data)
+	}
+
+	return fmt.Sprintf("bundler error %d: %s", e.Code, e.Message)
+}
+
+// GasEstimate is the result of eth_estimateUserOperationGas.
+type GasEstimate struct {
+	PreVerificationGas            *hexutil.Big `json:"preVerificationGas"`
+	VerificationGasLimit          *hexutil.Big `json:"verificationGasLimit"`
+	CallGasLimit                  *hexutil.Big `json:"callGasLimit"`
+	PaymasterVerificationGasLimit *hexutil.Big `json:"paymasterVerificationGasLimit,omitempty"` // Only returned for EntryPoint v0.7
+	PaymasterPostOpGasLimit       *hexutil.Big `json:"paymasterPostOpGasLimit,omitempty"`       // Only returned for EntryPoint v0.7
+}
+
+// UserOperationByHash is the result of eth_getUserOperationByHash. The block fields are
+// empty while the userop is still pending in the mempool.
+type UserOperationByHash struct {
+	UserOperation   json.RawMessage `json:"userOperation"`
+	EntryPoint      common.Address  `json:"entryPoint"`
+	BlockNumber     *hexutil.Big    `json:"blockNumber"`
+	BlockHash       *common.Hash    `json:"blockHash"`
+	TransactionHash *common.Hash    `json:"transactionHash"`
+}
+
+// UserOperationReceipt is the result of eth_getUserOperationReceipt.
+type UserOperationReceipt struct {
+	UserOpHash    common.Hash        `json:"userOpHash"`
+	EntryPoint    common.Address     `json:"entryPoint"`
+	Sender        common.Address     `json:"sender"`
+	Nonce         *hexutil.Big       `json:"nonce"`
+	Paymaster     common.Address     `json:"paymaster"`
+	ActualGasCost *hexutil.Big       `json:"actualGasCost"`
+	ActualGasUsed *hexutil.Big       `json:"actualGasUsed"`
+	Success       bool               `json:"success"`
+	Reason        string             `json:"reason,omitempty"`
+	Logs          []types.Log        `json:"logs"`
+	Receipt       TransactionReceipt `json:"receipt"`
+}
+
+// TransactionReceipt is the bundle transaction receipt embedded in a UserOperationReceipt.
+// Only the fields bundlers reliably return are decoded.
+type TransactionReceipt struct {
+	TransactionHash   common.Hash    `json:"transactionHash"`
+	BlockHash         common.Hash    `json:"blockHash"`
+	BlockNumber       *hexutil.Big   `json:"blockNumber"`
+	From              common.Address `json:"from"`
+	To                common.Address `json:"to"`
+	GasUsed           *hexutil.Big   `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big   `json:"effectiveGasPrice"`
+	Status            hexutil.Uint64 `json:"status"`
+	Logs              []types.Log    `json:"logs"`
+}
+
+// GasPrice is a fee suggestion for one speed tier.
+type GasPrice struct {
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas"`
+}
+
+// UserOperationGasPrice is the result of pimlico_getUserOperationGasPrice.
+type UserOperationGasPrice struct {
+	Slow     GasPrice `json:"slow"`
+	Standard GasPrice `json:"standard"`
+	Fast     GasPrice `json:"fast"`
+}
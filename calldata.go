@@ -0,0 +1,130 @@
+package goaa
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	account "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"strings"
+)
+
+// encodeExecute ABI-encodes a SimpleAccount execute(dest, value, func) call for the given target.
+func encodeExecute(target TargetParams) ([]byte, error) {
+	dest, value, data, err := decodeTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	simpleAccount, err := account.SimpleAccountMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return simpleAccount.Pack("execute", dest, value, data)
+}
+
+// decodeTarget validates a TargetParams and converts its string fields into the
+// types expected by the SimpleAccount ABI.
+func decodeTarget(target TargetParams) (common.Address, *big.Int, []byte, error) {
+	if !common.IsHexAddress(target.Target) {
+		return common.Address{}, nil, nil, fmt.Errorf("invalid target address %q", target.Target)
+	}
+
+	data := []byte{}
+	if target.Data != "" {
+		decoded, err := hexutil.Decode(target.Data)
+		if err != nil {
+			return common.Address{}, nil, nil, fmt.Errorf("invalid call data %q: %w", target.Data, err)
+		}
+		data = decoded
+	}
+
+	value := new(big.Int)
+	if v := strings.TrimSpace(target.Value); v != "" {
+		parsed, ok := parseWei(v)
+		if !ok {
+			return common.Address{}, nil, nil, fmt.Errorf("invalid value %q: expected a non-negative decimal or 0x-prefixed wei amount", target.Value)
+		}
+		value = parsed
+	}
+
+	return common.HexToAddress(target.Target), value, data, nil
+}
+
+// parseWei parses a wei amount written in decimal, or in hex with a 0x prefix. Unlike base 0
+// parsing, a leading zero stays decimal, and signs, 0b/0o prefixes and _ separators fail.
+func parseWei(v string) (*big.Int, bool) {
+	digits, base := v, 10
+	if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+		digits, base = v[2:], 16
+	}
+
+	if digits == "" || digits[0] == '+' || digits[0] == '-' {
+		return nil, false
+	}
+
+	return new(big.Int).SetString(digits, base)
+}
+
+// ErrBatchValueUnsupported is returned for batches sending value to an account that only has
+// executeBatch(address[],bytes[]), like the v0.6 SimpleAccount.
+var ErrBatchValueUnsupported = errors.New("the v0.6 SimpleAccount executeBatch cannot send value, send value-carrying calls one at a time")
+
+// executeBatchWithValueABI is the value-carrying executeBatch of the v0.7 SimpleAccount. The
+// v0.6 account in gen only accepts executeBatch(address[],bytes[]).
+const executeBatchWithValueABI = `[{"inputs":[{"internalType":"address[]","name":"dest","type":"address[]"},{"internalType":"uint256[]","name":"value","type":"uint256[]"},{"internalType":"bytes[]","name":"func","type":"bytes[]"}],"name":"executeBatch","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+// encodeExecuteBatch ABI-encodes a SimpleAccount executeBatch call for the given targets.
+// withValue selects the value-carrying variant; without it, targets sending value fail with
+// ErrBatchValueUnsupported.
+func encodeExecuteBatch(targets []TargetParams, withValue bool) ([]byte, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("batch must contain at least one target")
+	}
+
+	dests := make([]common.Address, len(targets))
+	values := make([]*big.Int, len(targets))
+	datas := make([][]byte, len(targets))
+
+	for i, target := range targets {
+		dest, value, data, err := decodeTarget(target)
+		if err != nil {
+			return nil, fmt.Errorf("target %d: %w", i, err)
+		}
+
+		if value.Sign() > 0 && !withValue {
+			return nil, fmt.Errorf("target %d: %w", i, ErrBatchValueUnsupported)
+		}
+
+		dests[i], values[i], datas[i] = dest, value, data
+	}
+
+	if withValue {
+		batchWithValue, err := abi.JSON(strings.NewReader(executeBatchWithValueABI))
+		if err != nil {
+			return nil, err
+		}
+
+		return batchWithValue.Pack("executeBatch", dests, values, datas)
+	}
+
+	simpleAccount, err := account.SimpleAccountMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return simpleAccount.Pack("executeBatch", dests, datas)
+}
+
+// encodeCalls encodes a single target as execute and several as executeBatch. The v0.7
+// SimpleAccount only offers the value-carrying executeBatch.
+func (sap *SmartAccountProvider) encodeCalls(targets []TargetParams) ([]byte, error) {
+	if len(targets) == 1 {
+		return encodeExecute(targets[0])
+	}
+
+	return encodeExecuteBatch(targets, sap.EntryPointVersion == EntryPointV07)
+}
@@ -0,0 +1,96 @@
+package goaa
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"testing"
+)
+
+func TestEncodeExecuteBatch(t *testing.T) {
+	plain := []TargetParams{
+		{Target: "0x94f3178AcB40d0E9c6967108e3711CF047D3240A", Data: "0x"},
+		{Target: "0x1306b01bC3e4AD202612D3843387e94737673F53", Data: "0xdeadbeef"},
+	}
+	valued := []TargetParams{
+		plain[0],
+		{Target: "0x1306b01bC3e4AD202612D3843387e94737673F53", Value: "1000"},
+	}
+
+	tests := []struct {
+		name      string
+		targets   []TargetParams
+		withValue bool
+		selector  string
+		err       error
+	}{
+		{name: "v0.6 without value", targets: plain, selector: "0x18dfb3c7"},
+		{name: "v0.6 with value", targets: valued, err: ErrBatchValueUnsupported},
+		{name: "v0.7 without value", targets: plain, withValue: true, selector: "0x47e1da2a"},
+		{name: "v0.7 with value", targets: valued, withValue: true, selector: "0x47e1da2a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := encodeExecuteBatch(tt.targets, tt.withValue)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := hexutil.Encode(data[:4]); got != tt.selector {
+				t.Errorf("selector = %s, want %s", got, tt.selector)
+			}
+		})
+	}
+}
+
+func TestDecodeTargetValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string // Decimal wei, empty when the value is rejected
+	}{
+		{value: "", want: "0"},
+		{value: "0", want: "0"},
+		{value: "1000", want: "1000"},
+		{value: "0100", want: "100"},
+		{value: " 42 ", want: "42"},
+		{value: "0x64", want: "100"},
+		{value: "0X64", want: "100"},
+		{value: "0x0100", want: "256"},
+		{value: "115792089237316195423570985008687907853269984665640564039457584007913129639935", want: "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+		{value: "0x"},
+		{value: "0b101"},
+		{value: "0o17"},
+		{value: "1_000"},
+		{value: "0x1_0"},
+		{value: "-1"},
+		{value: "+1"},
+		{value: "0x-1"},
+		{value: "1e18"},
+		{value: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			_, value, _, err := decodeTarget(TargetParams{Target: "0x94f3178AcB40d0E9c6967108e3711CF047D3240A", Value: tt.value})
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("value %q decoded as %s, want an error", tt.value, value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if value.String() != tt.want {
+				t.Errorf("value = %s, want %s", value, tt.want)
+			}
+		})
+	}
+}
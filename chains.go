@@ -0,0 +1,125 @@
+package goaa
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"strings"
+)
+
+// Canonical ERC-4337 deployments. They are created through deterministic deployers, so the
+// same addresses are used on every chain the contracts are deployed to.
+var (
+	CanonicalEntryPointV06           = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	CanonicalEntryPointV07           = common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+	CanonicalSimpleAccountFactoryV06 = common.HexToAddress("0x9406Cc6185a346906296840746125a0E44976454")
+	CanonicalSimpleAccountFactoryV07 = common.HexToAddress("0x91E60e0613810449d098b0b5Ec8b51A0FE8c8985")
+	CanonicalLightAccountFactoryV06  = common.HexToAddress("0x00004EC70002a32400f8ae005A26081065620D20") // LightAccount v1.1.0
+)
+
+// Chain describes a network with known ERC-4337 deployments.
+type Chain struct {
+	Name                    string         // Registry name, e.g. "sepolia"
+	ChainID                 *big.Int       // EIP-155 chain ID
+	EntryPointV06           common.Address // EntryPoint v0.6
+	EntryPointV07           common.Address // EntryPoint v0.7
+	SimpleAccountFactoryV06 common.Address // SimpleAccountFactory for EntryPoint v0.6
+	SimpleAccountFactoryV07 common.Address // SimpleAccountFactory for EntryPoint v0.7
+	LightAccountFactory     common.Address // Alchemy LightAccountFactory v1.1.0, for EntryPoint v0.6
+}
+
+// EntryPoint returns the chain's EntryPoint for version.
+func (c Chain) EntryPoint(version EntryPointVersion) common.Address {
+	if version == EntryPointV07 {
+		return c.EntryPointV07
+	}
+
+	return c.EntryPointV06
+}
+
+// SimpleAccountFactory returns the chain's SimpleAccountFactory for version.
+func (c Chain) SimpleAccountFactory(version EntryPointVersion) common.Address {
+	if version == EntryPointV07 {
+		return c.SimpleAccountFactoryV07
+	}
+
+	return c.SimpleAccountFactoryV06
+}
+
+// canonicalChain returns a registry entry using the canonical deployments.
+func canonicalChain(name string, chainID int64) Chain {
+	return Chain{
+		Name:                    name,
+		ChainID:                 big.NewInt(chainID),
+		EntryPointV06:           CanonicalEntryPointV06,
+		EntryPointV07:           CanonicalEntryPointV07,
+		SimpleAccountFactoryV06: CanonicalSimpleAccountFactoryV06,
+		SimpleAccountFactoryV07: CanonicalSimpleAccountFactoryV07,
+		LightAccountFactory:     CanonicalLightAccountFactoryV06,
+	}
+}
+
+// chains is the built-in registry.
+var chains = []Chain{
+	canonicalChain("mainnet", 1),
+	canonicalChain("sepolia", 11155111),
+	canonicalChain("polygon", 137),
+	canonicalChain("polygon-amoy", 80002),
+	canonicalChain("polygon-mumbai", 80001),
+	canonicalChain("optimism", 10),
+	canonicalChain("optimism-sepolia", 11155420),
+	canonicalChain("arbitrum", 42161),
+	canonicalChain("arbitrum-sepolia", 421614),
+	canonicalChain("base", 8453),
+	canonicalChain("base-sepolia", 84532),
+}
+
+// Chains returns the networks in the built-in registry.
+func Chains() []Chain {
+	return append([]Chain(nil), chains...)
+}
+
+// ChainByName looks a network up in the registry by name, ignoring case.
+func ChainByName(name string) (Chain, bool) {
+	for _, chain := range chains {
+		if strings.EqualFold(chain.Name, name) {
+			return chain, true
+		}
+	}
+
+	return Chain{}, false
+}
+
+// ChainByID looks a network up in the registry by chain ID.
+func ChainByID(chainID *big.Int) (Chain, bool) {
+	for _, chain := range chains {
+		if chain.ChainID.Cmp(chainID) == 0 {
+			return chain, true
+		}
+	}
+
+	return Chain{}, false
+}
+
+// resolveChain finds the registry entry for the node's chain. A configured name must exist
+// in the registry and match chainID; without one the entry is looked up by chainID and nil
+// is returned for networks the registry does not know.
+func resolveChain(name string, chainID *big.Int) (*Chain, error) {
+	if name == "" {
+		if chain, ok := ChainByID(chainID); ok {
+			return &chain, nil
+		}
+		return nil, nil
+	}
+
+	chain, ok := ChainByName(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown chain %q", name)
+	}
+
+	if chain.ChainID.Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("node is on chain %s, not %s (chain %s)", chainID, chain.Name, chain.ChainID)
+	}
+
+	return &chain, nil
+}
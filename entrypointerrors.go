@@ -0,0 +1,316 @@
+package goaa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pavankpdev/goaa/bundler"
+	entrypoint "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"regexp"
+)
+
+// Sentinel errors for the standard EntryPoint v0.6 AAxx reason codes. Errors carrying one of
+// these codes, whether decoded from revert data or returned by a bundler, match them with
+// errors.Is.
+var (
+	ErrSenderAlreadyConstructed  = errors.New("AA10 sender already constructed")
+	ErrInitCodeFailed            = errors.New("AA13 initCode failed or OOG")
+	ErrInitCodeWrongSender       = errors.New("AA14 initCode must return sender")
+	ErrInitCodeNoSender          = errors.New("AA15 initCode must create sender")
+	ErrAccountNotDeployed        = errors.New("AA20 account not deployed")
+	ErrPrefundNotPaid            = errors.New("AA21 didn't pay prefund")
+	ErrAccountExpired            = errors.New("AA22 expired or not due")
+	ErrAccountReverted           = errors.New("AA23 reverted (or OOG)")
+	ErrAccountSignature          = errors.New("AA24 signature error")
+	ErrInvalidNonce              = errors.New("AA25 invalid account nonce")
+	ErrPaymasterNotDeployed      = errors.New("AA30 paymaster not deployed")
+	ErrPaymasterDepositTooLow    = errors.New("AA31 paymaster deposit too low")
+	ErrPaymasterExpired          = errors.New("AA32 paymaster expired or not due")
+	ErrPaymasterReverted         = errors.New("AA33 reverted (or OOG)")
+	ErrPaymasterSignature        = errors.New("AA34 signature error")
+	ErrOverVerificationGasLimit  = errors.New("AA40 over verificationGasLimit")
+	ErrTooLittleVerificationGas  = errors.New("AA41 too little verificationGas")
+	ErrPostOpReverted            = errors.New("AA50 postOp reverted")
+	ErrPrefundBelowActualGasCost = errors.New("AA51 prefund below actualGasCost")
+	ErrInvalidBeneficiary        = errors.New("AA90 invalid beneficiary")
+	ErrBeneficiarySendFailed     = errors.New("AA91 failed send to beneficiary")
+	ErrInternalCallOnly          = errors.New("AA92 internal call only")
+	ErrInvalidPaymasterAndData   = errors.New("AA93 invalid paymasterAndData")
+	ErrGasValuesOverflow         = errors.New("AA94 gas values overflow")
+	ErrOutOfGas                  = errors.New("AA95 out of gas")
+	ErrInvalidAggregator         = errors.New("AA96 invalid aggregator")
+)
+
+// aaErrors maps AAxx codes to their sentinel errors.
+var aaErrors = map[string]error{
+	"AA10": ErrSenderAlreadyConstructed,
+	"AA13": ErrInitCodeFailed,
+	"AA14": ErrInitCodeWrongSender,
+	"AA15": ErrInitCodeNoSender,
+	"AA20": ErrAccountNotDeployed,
+	"AA21": ErrPrefundNotPaid,
+	"AA22": ErrAccountExpired,
+	"AA23": ErrAccountReverted,
+	"AA24": ErrAccountSignature,
+	"AA25": ErrInvalidNonce,
+	"AA30": ErrPaymasterNotDeployed,
+	"AA31": ErrPaymasterDepositTooLow,
+	"AA32": ErrPaymasterExpired,
+	"AA33": ErrPaymasterReverted,
+	"AA34": ErrPaymasterSignature,
+	"AA40": ErrOverVerificationGasLimit,
+	"AA41": ErrTooLittleVerificationGas,
+	"AA50": ErrPostOpReverted,
+	"AA51": ErrPrefundBelowActualGasCost,
+	"AA90": ErrInvalidBeneficiary,
+	"AA91": ErrBeneficiarySendFailed,
+	"AA92": ErrInternalCallOnly,
+	"AA93": ErrInvalidPaymasterAndData,
+	"AA94": ErrGasValuesOverflow,
+	"AA95": ErrOutOfGas,
+	"AA96": ErrInvalidAggregator,
+}
+
+// aaCodePattern finds an AAxx code in a reason string or bundler message.
+var aaCodePattern = regexp.MustCompile(`\bAA\d\d\b`)
+
+// aaError returns the sentinel error for the AAxx code in reason, or nil when it has none.
+func aaError(reason string) error {
+	return aaErrors[aaCodePattern.FindString(reason)]
+}
+
+// FailedOpError is the EntryPoint's FailedOp revert, raised when a userop fails validation.
+// It unwraps to the sentinel error of its AAxx reason code.
+type FailedOpError struct {
+	OpIndex *big.Int // Index of the failing op in the handleOps batch
+	Reason  string   // Reason string, starting with an AAxx code
+}
+
+// Error implements the error interface.
+func (e *FailedOpError) Error() string {
+	return fmt.Sprintf("FailedOp(%s, %q)", e.OpIndex, e.Reason)
+}
+
+// Unwrap returns the sentinel error of the reason's AAxx code.
+func (e *FailedOpError) Unwrap() error {
+	return aaError(e.Reason)
+}
+
+// SignatureValidationFailedError is the EntryPoint's SignatureValidationFailed revert,
+// raised when an aggregator rejects a bundle's signatures.
+type SignatureValidationFailedError struct {
+	Aggregator common.Address
+}
+
+// Error implements the error interface.
+func (e *SignatureValidationFailedError) Error() string {
+	return fmt.Sprintf("SignatureValidationFailed(%s)", e.Aggregator)
+}
+
+// RevertError is a plain Error(string) revert. It unwraps to the sentinel error of its
+// AAxx reason code, if any.
+type RevertError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("execution reverted: %s", e.Reason)
+}
+
+// Unwrap returns the sentinel error of the reason's AAxx code.
+func (e *RevertError) Unwrap() error {
+	return aaError(e.Reason)
+}
+
+// The EntryPoint's simulation methods always revert and deliver their results as custom
+// errors. The following types carry those results.
+
+// SenderAddressResult is the revert of getSenderAddress.
+type SenderAddressResult struct {
+	Sender common.Address // Address the initCode deploys
+}
+
+// Error implements the error interface.
+func (r *SenderAddressResult) Error() string {
+	return fmt.Sprintf("SenderAddressResult(%s)", r.Sender)
+}
+
+// ReturnInfo is the gas and validity summary of a simulated validation.
+type ReturnInfo struct {
+	PreOpGas         *big.Int // Gas used by validation, including preVerificationGas
+	Prefund          *big.Int // Required prefund of the op
+	SigFailed        bool     // Whether the account or paymaster reported a signature failure
+	ValidAfter       *big.Int // First timestamp the op is valid at
+	ValidUntil       *big.Int // Last timestamp the op is valid at, zero for no expiry
+	PaymasterContext []byte   // Context passed to the paymaster's postOp
+}
+
+// StakeInfo is the EntryPoint stake of an entity taking part in validation.
+type StakeInfo struct {
+	Stake           *big.Int
+	UnstakeDelaySec *big.Int
+}
+
+// AggregatorStakeInfo is the stake of the signature aggregator used by an account.
+type AggregatorStakeInfo struct {
+	Aggregator common.Address
+	StakeInfo  StakeInfo
+}
+
+// ValidationResult is the revert of simulateValidation. AggregatorInfo is only set when
+// the EntryPoint reverted with ValidationResultWithAggregation.
+type ValidationResult struct {
+	ReturnInfo     ReturnInfo
+	SenderInfo     StakeInfo
+	FactoryInfo    StakeInfo
+	PaymasterInfo  StakeInfo
+	AggregatorInfo *AggregatorStakeInfo
+}
+
+// Error implements the error interface.
+func (r *ValidationResult) Error() string {
+	return fmt.Sprintf("ValidationResult(preOpGas: %s, prefund: %s, sigFailed: %t)", r.ReturnInfo.PreOpGas, r.ReturnInfo.Prefund, r.ReturnInfo.SigFailed)
+}
+
+// ExecutionResult is the revert of simulateHandleOp. The Call fields are not part of the
+// revert; SimulateUserOp fills them in when the node can trace the simulation.
+type ExecutionResult struct {
+	PreOpGas      *big.Int // Gas used by validation
+	Paid          *big.Int // Total amount the op was charged
+	ValidAfter    *big.Int
+	ValidUntil    *big.Int
+	TargetSuccess bool   // Whether the optional target call succeeded
+	TargetResult  []byte // Return or revert data of the target call
+	CallTraced    bool   // Whether CallSuccess and CallResult are known
+	CallSuccess   bool   // Whether the op's own call succeeded
+	CallResult    []byte // Return or revert data of the op's own call
+}
+
+// Error implements the error interface.
+func (r *ExecutionResult) Error() string {
+	return fmt.Sprintf("ExecutionResult(preOpGas: %s, paid: %s, targetSuccess: %t)", r.PreOpGas, r.Paid, r.TargetSuccess)
+}
+
+// DecodeEntryPointError unpacks EntryPoint revert data into one of the typed errors of this
+// file. It returns nil when the data matches none of them.
+func DecodeEntryPointError(data []byte) error {
+	if len(data) < 4 {
+		return nil
+	}
+
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return &RevertError{Reason: reason}
+	}
+
+	entryPointABI, err := entrypoint.EntryPointMetaData.GetAbi()
+	if err != nil {
+		return nil
+	}
+
+	for name, abiErr := range entryPointABI.Errors {
+		if [4]byte(data[:4]) != [4]byte(abiErr.ID[:4]) {
+			continue
+		}
+
+		values, err := abiErr.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil
+		}
+
+		var result error
+		switch name {
+		case "FailedOp":
+			result = &FailedOpError{}
+		case "SignatureValidationFailed":
+			result = &SignatureValidationFailedError{}
+		case "SenderAddressResult":
+			result = &SenderAddressResult{}
+		case "ValidationResult":
+			result = &ValidationResult{}
+		case "ValidationResultWithAggregation":
+			result = &ValidationResult{AggregatorInfo: &AggregatorStakeInfo{}}
+		case "ExecutionResult":
+			result = &ExecutionResult{}
+		default:
+			return nil
+		}
+
+		if err := abiErr.Inputs.Copy(result, values); err != nil {
+			return nil
+		}
+
+		return result
+	}
+
+	return nil
+}
+
+// revertData extracts the revert payload attached to a node or bundler error, if any.
+func revertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if decoded, err := hexutil.Decode(data); err == nil {
+				return decoded, true
+			}
+		}
+	}
+
+	var bundlerErr *bundler.Error
+	if errors.As(err, &bundlerErr) && len(bundlerErr.Data) > 0 {
+		var data hexutil.Bytes
+		if json.Unmarshal(bundlerErr.Data, &data) == nil {
+			return data, true
+		}
+	}
+
+	return nil, false
+}
+
+// entryPointError is an error annotated with what it decodes to, so errors.Is and errors.As
+// see the typed EntryPoint error and its AAxx sentinel as well as the original error.
+type entryPointError struct {
+	err      error
+	decoded  error
+	fromData bool // decoded came from revert data rather than the error message
+}
+
+// Error implements the error interface.
+func (e *entryPointError) Error() string {
+	if e.fromData {
+		return fmt.Sprintf("%v: %v", e.err, e.decoded)
+	}
+
+	return e.err.Error()
+}
+
+// Unwrap returns the original and the decoded error.
+func (e *entryPointError) Unwrap() []error {
+	return []error{e.err, e.decoded}
+}
+
+// decodeEntryPointError annotates err with the EntryPoint error found in its revert data or,
+// failing that, the sentinel of an AAxx code in its message. Other errors are returned as-is.
+func decodeEntryPointError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if data, ok := revertData(err); ok {
+		if decoded := DecodeEntryPointError(data); decoded != nil {
+			return &entryPointError{err: err, decoded: decoded, fromData: true}
+		}
+	}
+
+	if sentinel := aaError(err.Error()); sentinel != nil {
+		return &entryPointError{err: err, decoded: sentinel}
+	}
+
+	return err
+}
@@ -0,0 +1,258 @@
+package goaa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pavankpdev/goaa/bundler"
+	entrypoint "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"testing"
+)
+
+// entryPointRevert ABI-encodes the EntryPoint custom error name with args.
+func entryPointRevert(t *testing.T, name string, args ...any) []byte {
+	t.Helper()
+
+	entryPointABI, err := entrypoint.EntryPointMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	abiErr, ok := entryPointABI.Errors[name]
+	if !ok {
+		t.Fatalf("EntryPoint ABI has no error %s", name)
+	}
+	data, err := abiErr.Inputs.Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return append(abiErr.ID[:4], data...)
+}
+
+// nodeError is a node error carrying revert data, as go-ethereum's rpc client returns.
+type nodeError struct {
+	message string
+	data    any
+}
+
+var _ rpc.DataError = (*nodeError)(nil)
+
+func (e *nodeError) Error() string  { return e.message }
+func (e *nodeError) ErrorData() any { return e.data }
+
+func TestDecodeEntryPointError(t *testing.T) {
+	address := common.HexToAddress("0xe93eca6595fe94091dc1af46aac2a8b5d7990770")
+	returnInfo := ReturnInfo{
+		PreOpGas:         big.NewInt(50000),
+		Prefund:          big.NewInt(1e15),
+		ValidAfter:       big.NewInt(0),
+		ValidUntil:       big.NewInt(1735689600),
+		PaymasterContext: []byte{},
+	}
+	stake := StakeInfo{Stake: big.NewInt(1e18), UnstakeDelaySec: big.NewInt(86400)}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		check    func(t *testing.T, err error)
+		sentinel error // AAxx sentinel the error must match, if any
+	}{
+		{
+			name: "FailedOp",
+			data: entryPointRevert(t, "FailedOp", big.NewInt(1), "AA25 invalid account nonce"),
+			check: func(t *testing.T, err error) {
+				var failed *FailedOpError
+				if !errors.As(err, &failed) || failed.OpIndex.Int64() != 1 || failed.Reason != "AA25 invalid account nonce" {
+					t.Errorf("got %#v, want FailedOp(1, AA25 invalid account nonce)", err)
+				}
+			},
+			sentinel: ErrInvalidNonce,
+		},
+		{
+			name: "Error(string) with an AAxx code",
+			data: revertWithReason("AA21 didn't pay prefund"),
+			check: func(t *testing.T, err error) {
+				var revert *RevertError
+				if !errors.As(err, &revert) || revert.Reason != "AA21 didn't pay prefund" {
+					t.Errorf("got %#v, want a RevertError", err)
+				}
+			},
+			sentinel: ErrPrefundNotPaid,
+		},
+		{
+			name: "Error(string) without a code",
+			data: revertWithReason("not owner"),
+			check: func(t *testing.T, err error) {
+				var revert *RevertError
+				if !errors.As(err, &revert) || revert.Reason != "not owner" {
+					t.Errorf("got %#v, want a RevertError", err)
+				}
+				if errors.Unwrap(err) != nil {
+					t.Errorf("RevertError without a code unwraps to %v", errors.Unwrap(err))
+				}
+			},
+		},
+		{
+			name: "SignatureValidationFailed",
+			data: entryPointRevert(t, "SignatureValidationFailed", address),
+			check: func(t *testing.T, err error) {
+				var failed *SignatureValidationFailedError
+				if !errors.As(err, &failed) || failed.Aggregator != address {
+					t.Errorf("got %#v, want SignatureValidationFailed(%s)", err, address)
+				}
+			},
+		},
+		{
+			name: "SenderAddressResult",
+			data: entryPointRevert(t, "SenderAddressResult", address),
+			check: func(t *testing.T, err error) {
+				var result *SenderAddressResult
+				if !errors.As(err, &result) || result.Sender != address {
+					t.Errorf("got %#v, want SenderAddressResult(%s)", err, address)
+				}
+			},
+		},
+		{
+			name: "ValidationResult",
+			data: entryPointRevert(t, "ValidationResult", returnInfo, stake, stake, stake),
+			check: func(t *testing.T, err error) {
+				var result *ValidationResult
+				if !errors.As(err, &result) || result.ReturnInfo.Prefund.Cmp(returnInfo.Prefund) != 0 || result.AggregatorInfo != nil {
+					t.Errorf("got %#v, want a ValidationResult without aggregator", err)
+				}
+			},
+		},
+		{
+			name: "ValidationResultWithAggregation",
+			data: entryPointRevert(t, "ValidationResultWithAggregation", returnInfo, stake, stake, stake, AggregatorStakeInfo{Aggregator: address, StakeInfo: stake}),
+			check: func(t *testing.T, err error) {
+				var result *ValidationResult
+				if !errors.As(err, &result) || result.AggregatorInfo == nil || result.AggregatorInfo.Aggregator != address {
+					t.Errorf("got %#v, want a ValidationResult with aggregator %s", err, address)
+				}
+			},
+		},
+		{
+			name: "ExecutionResult",
+			data: executionResultRevert(t, 12345, true, nil),
+			check: func(t *testing.T, err error) {
+				var result *ExecutionResult
+				if !errors.As(err, &result) || result.Paid.Int64() != 12345 || !result.TargetSuccess {
+					t.Errorf("got %#v, want ExecutionResult paying 12345", err)
+				}
+			},
+		},
+		{
+			name:  "unknown selector",
+			data:  hexutil.MustDecode("0xdeadbeef"),
+			check: wantNoError,
+		},
+		{
+			name:  "short data",
+			data:  hexutil.MustDecode("0x2209"),
+			check: wantNoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DecodeEntryPointError(tt.data)
+			tt.check(t, err)
+
+			if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.sentinel)
+			}
+		})
+	}
+}
+
+func wantNoError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+}
+
+func TestDecodeEntryPointErrorWrapped(t *testing.T) {
+	failedOp := entryPointRevert(t, "FailedOp", big.NewInt(0), "AA25 invalid account nonce")
+	encoded, err := json.Marshal(hexutil.Bytes(failedOp))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plain := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error // AAxx sentinel the decoded error must match, nil for none
+		fromData bool  // Whether a FailedOpError is decoded from revert data
+	}{
+		{
+			name:     "node error with hex data",
+			err:      &nodeError{message: "execution reverted", data: hexutil.Encode(failedOp)},
+			sentinel: ErrInvalidNonce,
+			fromData: true,
+		},
+		{
+			name:     "wrapped node error",
+			err:      fmt.Errorf("eth_call: %w", &nodeError{message: "execution reverted", data: hexutil.Encode(failedOp)}),
+			sentinel: ErrInvalidNonce,
+			fromData: true,
+		},
+		{
+			name:     "bundler error with hex data",
+			err:      &bundler.Error{Code: -32500, Message: "validation reverted", Data: encoded},
+			sentinel: ErrInvalidNonce,
+			fromData: true,
+		},
+		{
+			name:     "bundler message only",
+			err:      &bundler.Error{Code: -32500, Message: "AA21 didn't pay prefund"},
+			sentinel: ErrPrefundNotPaid,
+		},
+		{
+			name:     "node error with undecodable data falls back to the message",
+			err:      &nodeError{message: "AA24 signature error", data: "0xdeadbeef"},
+			sentinel: ErrAccountSignature,
+		},
+		{
+			name: "no code",
+			err:  plain,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := decodeEntryPointError(tt.err)
+
+			if !errors.Is(got, tt.err) {
+				t.Errorf("decoded error %v does not wrap the original %v", got, tt.err)
+			}
+			if tt.sentinel != nil && !errors.Is(got, tt.sentinel) {
+				t.Errorf("errors.Is(%v, %v) = false", got, tt.sentinel)
+			}
+			if tt.sentinel == nil && got != tt.err {
+				t.Errorf("got %v, want the original error returned as-is", got)
+			}
+
+			var failed *FailedOpError
+			if errors.As(got, &failed) != tt.fromData {
+				t.Errorf("errors.As(FailedOpError) = %t, want %t", !tt.fromData, tt.fromData)
+			}
+			if !tt.fromData && got.Error() != tt.err.Error() {
+				t.Errorf("message = %q, want the original %q", got.Error(), tt.err.Error())
+			}
+		})
+	}
+
+	if decodeEntryPointError(nil) != nil {
+		t.Error("decodeEntryPointError(nil) != nil")
+	}
+}
@@ -0,0 +1,80 @@
+package goaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	entrypoint "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"strings"
+)
+
+// resolveEntryPoint picks the EntryPoint the provider uses from those the bundler supports.
+// A configured address must be among them, otherwise the bundler's first one is used.
+func resolveEntryPoint(configured string, supported []common.Address) (common.Address, error) {
+	if configured == "" {
+		if len(supported) == 0 {
+			return common.Address{}, errors.New("no EntryPointAddress configured and the bundler reports no supported entry points")
+		}
+
+		return supported[0], nil
+	}
+
+	if !common.IsHexAddress(configured) {
+		return common.Address{}, fmt.Errorf("invalid entry point address %q", configured)
+	}
+
+	address := common.HexToAddress(configured)
+	for _, candidate := range supported {
+		if candidate == address {
+			return address, nil
+		}
+	}
+
+	listed := make([]string, len(supported))
+	for i, candidate := range supported {
+		listed[i] = candidate.Hex()
+	}
+
+	return common.Address{}, fmt.Errorf("bundler does not support entry point %s, it supports [%s]", address, strings.Join(listed, ", "))
+}
+
+// detectEntryPointVersion probes the contract at address with the getUserOpHash of each
+// EntryPoint release. Only the matching release answers, as the two take differently
+// shaped userops and so have different selectors.
+func detectEntryPointVersion(ctx context.Context, client *ethclient.Client, address common.Address) (EntryPointVersion, error) {
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if len(code) == 0 {
+		return "", fmt.Errorf("no contract deployed at entry point %s", address)
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+
+	v07, err := entrypoint.NewEntryPointV07Caller(address, client)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := v07.GetUserOpHash(opts, entrypoint.PackedUserOperation{Nonce: new(big.Int), PreVerificationGas: new(big.Int)}); err == nil {
+		return EntryPointV07, nil
+	}
+
+	v06, err := entrypoint.NewEntryPointCaller(address, client)
+	if err != nil {
+		return "", err
+	}
+
+	probe := buildUserOp(common.Address{}, new(big.Int), nil, nil)
+	if _, err := v06.GetUserOpHash(opts, probe.ToEntryPoint()); err == nil {
+		return EntryPointV06, nil
+	}
+
+	return "", fmt.Errorf("contract at %s is neither an EntryPoint v0.6 nor v0.7", address)
+}
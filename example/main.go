@@ -1,27 +1,34 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/pavankpdev/goaa"
 	"math/big"
+	"os"
+	"time"
 )
 
 func main() {
 
-	const RPC = "https://polygon-mumbai.g.alchemy.com/v2/u-FhnHbTFL8OASxmdclXSWKS-YcypJzH"
-	const SmartAccountFactoryAddress = "0x9406Cc6185a346906296840746125a0E44976454" // https://docs.alchemy.com/docs/creating-a-smart-contract-account-and-sending-userops#1b-get-constants
-	const PrivateKey = "0x1934c4fa3a8c7130c55b4b2933657b584102c02e6fdc682394728822a714404e"
+	// A Sepolia endpoint that also serves the bundler methods, and the owner's hex private key.
+	RPC := os.Getenv("GOAA_RPC_URL")
+	PrivateKey := os.Getenv("GOAA_OWNER_PRIVATE_KEY")
+	if RPC == "" || PrivateKey == "" {
+		fmt.Println("Set GOAA_RPC_URL and GOAA_OWNER_PRIVATE_KEY to run the example")
+		os.Exit(1)
+	}
 
 	eth := big.NewFloat(0.1)
 	wei := new(big.Float)
 	wei.Mul(eth, big.NewFloat(params.Ether))
 
 	SAParams := goaa.SmartAccountProviderParams{
-		OwnerPrivateKey:            PrivateKey,
-		RPC:                        RPC,
-		EntryPointAddress:          "0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789",
-		SmartAccountFactoryAddress: SmartAccountFactoryAddress,
+		OwnerPrivateKey: PrivateKey,
+		RPC:             RPC,
+		Chain:           "sepolia",
+		AccountIndex:    1,
 	}
 
 	client, err := goaa.NewSmartAccountProvider(SAParams)
@@ -31,21 +38,26 @@ func main() {
 		panic(err)
 	}
 
-	address, err := client.GetSmartAccountAddress(1)
-	if err != nil {
-		panic(err)
-	}
-	fmt.Printf("My samrt account address is %v\n", address)
+	fmt.Printf("My samrt account address is %v\n", client.Account)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
 
-	nonce, err := client.SendUserOpsTransaction(goaa.TargetParams{
+	userOpHash, err := client.SendUserOpsTransactionContext(ctx, goaa.TargetParams{
 		Target: "0x94f3178AcB40d0E9c6967108e3711CF047D3240A",
 		Data:   "0x",
-		Value:  wei.String(),
+		Value:  wei.Text('f', 0),
 	})
 
 	if err != nil {
 		panic(err)
 	}
-	fmt.Printf("My samrt account address is %v\n", nonce)
+	fmt.Printf("My userop hash is %v\n", userOpHash)
+
+	receipt, err := client.WaitForUserOperationReceipt(ctx, userOpHash)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Included in %v, success: %v\n", receipt.Receipt.TransactionHash, receipt.Success)
 
 }
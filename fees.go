@@ -0,0 +1,223 @@
+package goaa
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pavankpdev/goaa/bundler"
+	"math/big"
+	"sync/atomic"
+)
+
+// Fees holds the EIP-1559 fee fields of a userop.
+type Fees struct {
+	MaxFeePerGas         *big.Int // Upper bound on the total price per gas
+	MaxPriorityFeePerGas *big.Int // Tip per gas paid on top of the base fee
+}
+
+// FeeOracle suggests the fees for the next userop.
+type FeeOracle interface {
+	SuggestFees(ctx context.Context) (Fees, error)
+}
+
+// FeeSpeed selects how aggressively NetworkFeeOracle prices userops.
+type FeeSpeed int
+
+const (
+	FeeStandard FeeSpeed = iota // Median tip, 1.5x base fee headroom
+	FeeFast                     // 90th percentile tip, 2x base fee headroom
+	FeeSlow                     // 10th percentile tip, 1.1x base fee headroom
+)
+
+// feeHistoryBlocks is the number of recent blocks sampled by NetworkFeeOracle.
+const feeHistoryBlocks = 10
+
+// rewardPercentile returns the eth_feeHistory reward percentile used for the speed.
+func (s FeeSpeed) rewardPercentile() float64 {
+	switch s {
+	case FeeFast:
+		return 90
+	case FeeSlow:
+		return 10
+	default:
+		return 50
+	}
+}
+
+// baseFeePercent returns the headroom, in percent of the current base fee, for the speed.
+func (s FeeSpeed) baseFeePercent() int64 {
+	switch s {
+	case FeeFast:
+		return 200
+	case FeeSlow:
+		return 110
+	default:
+		return 150
+	}
+}
+
+// FixedFeeOracle always suggests the same fees.
+type FixedFeeOracle Fees
+
+// SuggestFees implements FeeOracle.
+func (f FixedFeeOracle) SuggestFees(context.Context) (Fees, error) {
+	if f.MaxFeePerGas == nil || f.MaxPriorityFeePerGas == nil {
+		return Fees{}, errors.New("fixed fee oracle needs both MaxFeePerGas and MaxPriorityFeePerGas")
+	}
+
+	return Fees(f), nil
+}
+
+// NetworkFeeOracle derives fees from the node's fee history and tip suggestion, preferring
+// the bundler's own gas price extensions when it serves them.
+type NetworkFeeOracle struct {
+	Client    *ethclient.Client // Node used for eth_feeHistory and eth_maxPriorityFeePerGas
+	Bundler   *bundler.Client   // Optional bundler probed for pimlico_ and rundler_ fee methods
+	Speed     FeeSpeed          // Pricing tier
+	MaxFeeCap *big.Int          // Optional hard cap on maxFeePerGas
+
+	noGasPrice    atomic.Bool // Bundler does not serve pimlico_getUserOperationGasPrice
+	noPriorityFee atomic.Bool // Bundler does not serve rundler_maxPriorityFeePerGas
+}
+
+// SuggestFees implements FeeOracle.
+func (o *NetworkFeeOracle) SuggestFees(ctx context.Context) (Fees, error) {
+	if fees, ok := o.bundlerGasPrice(ctx); ok {
+		return o.capped(fees), nil
+	}
+
+	tip, err := o.bundlerPriorityFee(ctx)
+	if err != nil {
+		if tip, err = o.Client.SuggestGasTipCap(ctx); err != nil {
+			return Fees{}, err
+		}
+	}
+
+	history, err := o.Client.FeeHistory(ctx, feeHistoryBlocks, nil, []float64{o.Speed.rewardPercentile()})
+	if err != nil {
+		return Fees{}, err
+	}
+
+	if len(history.BaseFee) == 0 {
+		return Fees{}, errors.New("node returned an empty fee history")
+	}
+
+	// The last base fee is the one predicted for the next block.
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	if reward := averageReward(history.Reward); reward != nil && reward.Cmp(tip) > 0 {
+		tip = reward
+	}
+
+	maxFee := new(big.Int).Mul(baseFee, big.NewInt(o.Speed.baseFeePercent()))
+	maxFee.Div(maxFee, big.NewInt(100))
+	maxFee.Add(maxFee, tip)
+
+	return o.capped(Fees{MaxFeePerGas: maxFee, MaxPriorityFeePerGas: tip}), nil
+}
+
+// bundlerGasPrice returns the tier matching Speed from pimlico_getUserOperationGasPrice.
+func (o *NetworkFeeOracle) bundlerGasPrice(ctx context.Context) (Fees, bool) {
+	if o.Bundler == nil || o.noGasPrice.Load() {
+		return Fees{}, false
+	}
+
+	price, err := o.Bundler.UserOperationGasPrice(ctx)
+	if err != nil {
+		if bundler.IsMethodNotFound(err) {
+			o.noGasPrice.Store(true)
+		}
+		return Fees{}, false
+	}
+
+	tier := price.Standard
+	switch o.Speed {
+	case FeeFast:
+		tier = price.Fast
+	case FeeSlow:
+		tier = price.Slow
+	}
+
+	if tier.MaxFeePerGas == nil || tier.MaxPriorityFeePerGas == nil {
+		return Fees{}, false
+	}
+
+	return Fees{MaxFeePerGas: tier.MaxFeePerGas.ToInt(), MaxPriorityFeePerGas: tier.MaxPriorityFeePerGas.ToInt()}, true
+}
+
+// bundlerPriorityFee returns the bundler's minimum tip from rundler_maxPriorityFeePerGas.
+func (o *NetworkFeeOracle) bundlerPriorityFee(ctx context.Context) (*big.Int, error) {
+	if o.Bundler == nil || o.noPriorityFee.Load() {
+		return nil, errors.New("bundler priority fee unavailable")
+	}
+
+	tip, err := o.Bundler.MaxPriorityFeePerGas(ctx)
+	if err != nil {
+		if bundler.IsMethodNotFound(err) {
+			o.noPriorityFee.Store(true)
+		}
+		return nil, err
+	}
+
+	return tip, nil
+}
+
+// capped clamps the fees to MaxFeeCap, keeping the tip no larger than the max fee.
+func (o *NetworkFeeOracle) capped(fees Fees) Fees {
+	if o.MaxFeeCap != nil && fees.MaxFeePerGas.Cmp(o.MaxFeeCap) > 0 {
+		fees.MaxFeePerGas = new(big.Int).Set(o.MaxFeeCap)
+	}
+
+	if fees.MaxPriorityFeePerGas.Cmp(fees.MaxFeePerGas) > 0 {
+		fees.MaxPriorityFeePerGas = new(big.Int).Set(fees.MaxFeePerGas)
+	}
+
+	return fees
+}
+
+// averageReward averages the single-percentile rewards of a fee history, or returns nil
+// when the node reported none.
+func averageReward(rewards [][]*big.Int) *big.Int {
+	sum := new(big.Int)
+	count := int64(0)
+
+	for _, block := range rewards {
+		if len(block) == 0 || block[0] == nil {
+			continue
+		}
+		sum.Add(sum, block[0])
+		count++
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	return sum.Div(sum, big.NewInt(count))
+}
+
+// fillFees populates the fee fields of uo from the provider's FeeOracle, except for the
+// fields set in overrides.
+func (sap *SmartAccountProvider) fillFees(ctx context.Context, uo *UserOperation, overrides GasOverrides) error {
+	maxFee := overrides.MaxFeePerGas
+	maxPriority := overrides.MaxPriorityFeePerGas
+
+	if maxFee == nil || maxPriority == nil {
+		fees, err := sap.FeeOracle.SuggestFees(ctx)
+		if err != nil {
+			return err
+		}
+
+		if maxFee == nil {
+			maxFee = fees.MaxFeePerGas
+		}
+		if maxPriority == nil {
+			maxPriority = fees.MaxPriorityFeePerGas
+		}
+	}
+
+	uo.MaxFeePerGas = maxFee
+	uo.MaxPriorityFeePerGas = maxPriority
+
+	return nil
+}
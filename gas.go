@@ -0,0 +1,152 @@
+package goaa
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pavankpdev/goaa/bundler"
+	"math/big"
+)
+
+// dummySignature is a well-formed 65-byte ECDSA signature used while estimating gas. It
+// does not recover to the owner, but makes SimpleAccount run its full validation path.
+var dummySignature = hexutil.MustDecode("0xfffffffffffffffffffffffffffffff0000000000000000000000000000000007aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1c")
+
+// GasOverrides replaces individual userop gas and fee fields. Nil fields are left to
+// estimation and the provider's FeeOracle.
+type GasOverrides struct {
+	CallGasLimit         *big.Int // Gas available to the account's execution phase
+	VerificationGasLimit *big.Int // Gas available to deployment and validation
+	PreVerificationGas   *big.Int // Gas paid to the bundler for calldata and overhead
+	MaxFeePerGas         *big.Int // Upper bound on the total price per gas
+	MaxPriorityFeePerGas *big.Int // Tip per gas paid on top of the base fee
+}
+
+// GasMultipliers scales the bundler's gas estimates to leave a safety margin. A zero value
+// leaves the matching estimate unchanged.
+type GasMultipliers struct {
+	CallGasLimit         float64 // E.g. 1.2 adds 20% to the estimated callGasLimit
+	VerificationGasLimit float64 // Multiplier for the estimated verificationGasLimit
+	PreVerificationGas   float64 // Multiplier for the estimated preVerificationGas
+}
+
+// merge returns the overrides with every non-nil field of o applied on top.
+func (g GasOverrides) merge(o GasOverrides) GasOverrides {
+	if o.CallGasLimit != nil {
+		g.CallGasLimit = o.CallGasLimit
+	}
+	if o.VerificationGasLimit != nil {
+		g.VerificationGasLimit = o.VerificationGasLimit
+	}
+	if o.PreVerificationGas != nil {
+		g.PreVerificationGas = o.PreVerificationGas
+	}
+	if o.MaxFeePerGas != nil {
+		g.MaxFeePerGas = o.MaxFeePerGas
+	}
+	if o.MaxPriorityFeePerGas != nil {
+		g.MaxPriorityFeePerGas = o.MaxPriorityFeePerGas
+	}
+
+	return g
+}
+
+// complete reports whether every gas limit is overridden, making estimation unnecessary.
+func (g GasOverrides) complete() bool {
+	return g.CallGasLimit != nil && g.VerificationGasLimit != nil && g.PreVerificationGas != nil
+}
+
+// applyMultiplier scales value by multiplier, rounding up. Non-positive multipliers are ignored.
+func applyMultiplier(value *big.Int, multiplier float64) *big.Int {
+	if value == nil || multiplier <= 0 {
+		return value
+	}
+
+	scaled := new(big.Float).Mul(new(big.Float).SetInt(value), big.NewFloat(multiplier))
+	result, accuracy := scaled.Int(nil)
+	if accuracy == big.Below {
+		result.Add(result, common.Big1)
+	}
+
+	return result
+}
+
+// fillGas populates the gas fields of uo. Overridden fields are used as-is, the rest come
+// from eth_estimateUserOperationGas scaled by the provider's GasMultipliers. When the
+// paymaster supplies the gas limits itself there is no estimate and the rest stay zero
+// until its final data arrives.
+func (sap *SmartAccountProvider) fillGas(ctx context.Context, uo *UserOperation, overrides GasOverrides) error {
+	callGasLimit := overrides.CallGasLimit
+	verificationGasLimit := overrides.VerificationGasLimit
+	preVerificationGas := overrides.PreVerificationGas
+
+	if _, ok := sap.Paymaster.(gasLimitSupplier); !ok && !overrides.complete() {
+		draft := uo.Copy()
+		draft.Signature = dummySignature
+		draft.CallGasLimit = new(big.Int)
+		draft.VerificationGasLimit = new(big.Int)
+		draft.PreVerificationGas = new(big.Int)
+
+		payload, err := sap.rpcUserOp(draft)
+		if err != nil {
+			return err
+		}
+
+		estimate, err := sap.Bundler.EstimateUserOperationGas(ctx, payload, common.HexToAddress(sap.Contracts.entrypoint))
+		if err != nil {
+			return decodeEntryPointError(err)
+		}
+
+		if estimate.CallGasLimit == nil || estimate.VerificationGasLimit == nil || estimate.PreVerificationGas == nil {
+			return errors.New("bundler gas estimate is missing callGasLimit, verificationGasLimit or preVerificationGas")
+		}
+
+		if callGasLimit == nil {
+			callGasLimit = applyMultiplier(estimate.CallGasLimit.ToInt(), sap.GasMultipliers.CallGasLimit)
+		}
+		if verificationGasLimit == nil {
+			verificationGasLimit = applyMultiplier(estimate.VerificationGasLimit.ToInt(), sap.GasMultipliers.VerificationGasLimit)
+		}
+		if preVerificationGas == nil {
+			preVerificationGas = applyMultiplier(estimate.PreVerificationGas.ToInt(), sap.GasMultipliers.PreVerificationGas)
+		}
+
+		if sap.EntryPointVersion == EntryPointV07 {
+			if err := raisePaymasterGasLimits(uo, estimate); err != nil {
+				return err
+			}
+		}
+	}
+
+	uo.CallGasLimit = orZero(callGasLimit)
+	uo.VerificationGasLimit = orZero(verificationGasLimit)
+	uo.PreVerificationGas = orZero(preVerificationGas)
+
+	return nil
+}
+
+// raisePaymasterGasLimits raises the paymaster gas limits in v0.7 paymasterAndData to the
+// bundler's estimates, keeping the paymaster's own values where they are higher.
+func raisePaymasterGasLimits(uo *UserOperation, estimate *bundler.GasEstimate) error {
+	if len(uo.PaymasterAndData) < paymasterFieldsLength {
+		return nil
+	}
+
+	verificationGasLimit, postOpGasLimit := paymasterGasLimits(uo.PaymasterAndData)
+	if estimate.PaymasterVerificationGasLimit != nil && estimate.PaymasterVerificationGasLimit.ToInt().Cmp(verificationGasLimit) > 0 {
+		verificationGasLimit = estimate.PaymasterVerificationGasLimit.ToInt()
+	}
+	if estimate.PaymasterPostOpGasLimit != nil && estimate.PaymasterPostOpGasLimit.ToInt().Cmp(postOpGasLimit) > 0 {
+		postOpGasLimit = estimate.PaymasterPostOpGasLimit.ToInt()
+	}
+
+	paymaster := common.BytesToAddress(uo.PaymasterAndData[:common.AddressLength])
+	paymasterAndData, err := packPaymasterAndData(paymaster, verificationGasLimit, postOpGasLimit, uo.PaymasterAndData[paymasterFieldsLength:])
+	if err != nil {
+		return err
+	}
+	uo.PaymasterAndData = paymasterAndData
+
+	return nil
+}
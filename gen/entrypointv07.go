@@ -0,0 +1,2409 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package gen
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// PackedUserOperation is an auto generated low-level Go binding around an user-defined struct.
+type PackedUserOperation struct {
+	Sender             common.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte
+	PreVerificationGas *big.Int
+	GasFees            [32]byte
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+// EntryPointV07MetaData contains all meta data concerning the EntryPointV07 contract.
+var EntryPointV07MetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"},{\"internalType\":\"bytes\",\"name\":\"ret\",\"type\":\"bytes\"}],\"name\":\"DelegateAndRevert\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"opIndex\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"reason\",\"type\":\"string\"}],\"name\":\"FailedOp\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"opIndex\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"reason\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"inner\",\"type\":\"bytes\"}],\"name\":\"FailedOpWithRevert\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"returnData\",\"type\":\"bytes\"}],\"name\":\"PostOpReverted\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ReentrancyGuardReentrantCall\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"SenderAddressResult\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"aggregator\",\"type\":\"address\"}],\"name\":\"SignatureValidationFailed\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"userOpHash\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"factory\",\"type\":\"address\",\"indexed\":false},{\"internalType\":\"address\",\"name\":\"paymaster\",\"type\":\"address\",\"indexed\":false}],\"name\":\"AccountDeployed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"BeforeExecution\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"uint256\",\"name\":\"totalDeposit\",\"type\":\"uint256\",\"indexed\":false}],\"name\":\"Deposited\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"userOpHash\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\",\"indexed\":false},{\"internalType\":\"bytes\",\"name\":\"revertReason\",\"type\":\"bytes\",\"indexed\":false}],\"name\":\"PostOpRevertReason\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"aggregator\",\"type\":\"address\",\"indexed\":true}],\"name\":\"SignatureAggregatorChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"uint256\",\"name\":\"totalStaked\",\"type\":\"uint256\",\"indexed\":false},{\"internalType\":\"uint256\",\"name\":\"unstakeDelaySec\",\"type\":\"uint256\",\"indexed\":false}],\"name\":\"StakeLocked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"uint256\",\"name\":\"withdrawTime\",\"type\":\"uint256\",\"indexed\":false}],\"name\":\"StakeUnlocked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"withdrawAddress\",\"type\":\"address\",\"indexed\":false},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false}],\"name\":\"StakeWithdrawn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"userOpHash\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"paymaster\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\",\"indexed\":false},{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\",\"indexed\":false},{\"internalType\":\"uint256\",\"name\":\"actualGasCost\",\"type\":\"uint256\",\"indexed\":false},{\"internalType\":\"uint256\",\"name\":\"actualGasUsed\",\"type\":\"uint256\",\"indexed\":false}],\"name\":\"UserOperationEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"userOpHash\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\",\"indexed\":false}],\"name\":\"UserOperationPrefundTooLow\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"userOpHash\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\",\"indexed\":false},{\"internalType\":\"bytes\",\"name\":\"revertReason\",\"type\":\"bytes\",\"indexed\":false}],\"name\":\"UserOperationRevertReason\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"withdrawAddress\",\"type\":\"address\",\"indexed\":false},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false}],\"name\":\"Withdrawn\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"unstakeDelaySec\",\"type\":\"uint32\"}],\"name\":\"addStake\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"delegateAndRevert\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"depositTo\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"deposits\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"staked\",\"type\":\"bool\"},{\"internalType\":\"uint112\",\"name\":\"stake\",\"type\":\"uint112\"},{\"internalType\":\"uint32\",\"name\":\"unstakeDelaySec\",\"type\":\"uint32\"},{\"internalType\":\"uint48\",\"name\":\"withdrawTime\",\"type\":\"uint48\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint192\",\"name\":\"key\",\"type\":\"uint192\"}],\"name\":\"getNonce\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"}],\"name\":\"getSenderAddress\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structPackedUserOperation\",\"name\":\"userOp\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"bytes32\",\"name\":\"accountGasLimits\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"preVerificationGas\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"gasFees\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}]}],\"name\":\"getUserOpHash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structPackedUserOperation[]\",\"name\":\"ops\",\"type\":\"tuple[]\",\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"bytes32\",\"name\":\"accountGasLimits\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"preVerificationGas\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"gasFees\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}]},{\"internalType\":\"addresspayable\",\"name\":\"beneficiary\",\"type\":\"address\"}],\"name\":\"handleOps\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint192\",\"name\":\"key\",\"type\":\"uint192\"}],\"name\":\"incrementNonce\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint192\",\"name\":\"\",\"type\":\"uint192\"}],\"name\":\"nonceSequenceNumber\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unlockStake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"addresspayable\",\"name\":\"withdrawAddress\",\"type\":\"address\"}],\"name\":\"withdrawStake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"addresspayable\",\"name\":\"withdrawAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"withdrawAmount\",\"type\":\"uint256\"}],\"name\":\"withdrawTo\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+}
+
+// EntryPointV07ABI is the input ABI used to generate the binding from.
+// Deprecated: Use EntryPointV07MetaData.ABI instead.
+var EntryPointV07ABI = EntryPointV07MetaData.ABI
+
+// EntryPointV07 is an auto generated Go binding around an Ethereum contract.
+type EntryPointV07 struct {
+	EntryPointV07Caller     // Read-only binding to the contract
+	EntryPointV07Transactor // Write-only binding to the contract
+	EntryPointV07Filterer   // Log filterer for contract events
+}
+
+// EntryPointV07Caller is an auto generated read-only Go binding around an Ethereum contract.
+type EntryPointV07Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EntryPointV07Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type EntryPointV07Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EntryPointV07Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type EntryPointV07Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EntryPointV07Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type EntryPointV07Session struct {
+	Contract     *EntryPointV07    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// EntryPointV07CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type EntryPointV07CallerSession struct {
+	Contract *EntryPointV07Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// EntryPointV07TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type EntryPointV07TransactorSession struct {
+	Contract     *EntryPointV07Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// EntryPointV07Raw is an auto generated low-level Go binding around an Ethereum contract.
+type EntryPointV07Raw struct {
+	Contract *EntryPointV07 // Generic contract binding to access the raw methods on
+}
+
+// EntryPointV07CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type EntryPointV07CallerRaw struct {
+	Contract *EntryPointV07Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// EntryPointV07TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type EntryPointV07TransactorRaw struct {
+	Contract *EntryPointV07Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewEntryPointV07 creates a new instance of EntryPointV07, bound to a specific deployed contract.
+func NewEntryPointV07(address common.Address, backend bind.ContractBackend) (*EntryPointV07, error) {
+	contract, err := bindEntryPointV07(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07{EntryPointV07Caller: EntryPointV07Caller{contract: contract}, EntryPointV07Transactor: EntryPointV07Transactor{contract: contract}, EntryPointV07Filterer: EntryPointV07Filterer{contract: contract}}, nil
+}
+
+// NewEntryPointV07Caller creates a new read-only instance of EntryPointV07, bound to a specific deployed contract.
+func NewEntryPointV07Caller(address common.Address, caller bind.ContractCaller) (*EntryPointV07Caller, error) {
+	contract, err := bindEntryPointV07(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07Caller{contract: contract}, nil
+}
+
+// NewEntryPointV07Transactor creates a new write-only instance of EntryPointV07, bound to a specific deployed contract.
+func NewEntryPointV07Transactor(address common.Address, transactor bind.ContractTransactor) (*EntryPointV07Transactor, error) {
+	contract, err := bindEntryPointV07(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07Transactor{contract: contract}, nil
+}
+
+// NewEntryPointV07Filterer creates a new log filterer instance of EntryPointV07, bound to a specific deployed contract.
+func NewEntryPointV07Filterer(address common.Address, filterer bind.ContractFilterer) (*EntryPointV07Filterer, error) {
+	contract, err := bindEntryPointV07(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07Filterer{contract: contract}, nil
+}
+
+// bindEntryPointV07 binds a generic wrapper to an already deployed contract.
+func bindEntryPointV07(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := EntryPointV07MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_EntryPointV07 *EntryPointV07Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _EntryPointV07.Contract.EntryPointV07Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_EntryPointV07 *EntryPointV07Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.EntryPointV07Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_EntryPointV07 *EntryPointV07Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.EntryPointV07Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_EntryPointV07 *EntryPointV07CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _EntryPointV07.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_EntryPointV07 *EntryPointV07TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_EntryPointV07 *EntryPointV07TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.contract.Transact(opts, method, params...)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_EntryPointV07 *EntryPointV07Caller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _EntryPointV07.contract.Call(opts, &out, "balanceOf", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_EntryPointV07 *EntryPointV07Session) BalanceOf(account common.Address) (*big.Int, error) {
+	return _EntryPointV07.Contract.BalanceOf(&_EntryPointV07.CallOpts, account)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_EntryPointV07 *EntryPointV07CallerSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _EntryPointV07.Contract.BalanceOf(&_EntryPointV07.CallOpts, account)
+}
+
+// Deposits is a free data retrieval call binding the contract method 0xfc7e286d.
+//
+// Solidity: function deposits(address ) view returns(uint256 deposit, bool staked, uint112 stake, uint32 unstakeDelaySec, uint48 withdrawTime)
+func (_EntryPointV07 *EntryPointV07Caller) Deposits(opts *bind.CallOpts, arg0 common.Address) (struct {
+	Deposit         *big.Int
+	Staked          bool
+	Stake           *big.Int
+	UnstakeDelaySec uint32
+	WithdrawTime    *big.Int
+}, error) {
+	var out []interface{}
+	err := _EntryPointV07.contract.Call(opts, &out, "deposits", arg0)
+
+	outstruct := new(struct {
+		Deposit         *big.Int
+		Staked          bool
+		Stake           *big.Int
+		UnstakeDelaySec uint32
+		WithdrawTime    *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Deposit = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Staked = *abi.ConvertType(out[1], new(bool)).(*bool)
+	outstruct.Stake = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.UnstakeDelaySec = *abi.ConvertType(out[3], new(uint32)).(*uint32)
+	outstruct.WithdrawTime = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Deposits is a free data retrieval call binding the contract method 0xfc7e286d.
+//
+// Solidity: function deposits(address ) view returns(uint256 deposit, bool staked, uint112 stake, uint32 unstakeDelaySec, uint48 withdrawTime)
+func (_EntryPointV07 *EntryPointV07Session) Deposits(arg0 common.Address) (struct {
+	Deposit         *big.Int
+	Staked          bool
+	Stake           *big.Int
+	UnstakeDelaySec uint32
+	WithdrawTime    *big.Int
+}, error) {
+	return _EntryPointV07.Contract.Deposits(&_EntryPointV07.CallOpts, arg0)
+}
+
+// Deposits is a free data retrieval call binding the contract method 0xfc7e286d.
+//
+// Solidity: function deposits(address ) view returns(uint256 deposit, bool staked, uint112 stake, uint32 unstakeDelaySec, uint48 withdrawTime)
+func (_EntryPointV07 *EntryPointV07CallerSession) Deposits(arg0 common.Address) (struct {
+	Deposit         *big.Int
+	Staked          bool
+	Stake           *big.Int
+	UnstakeDelaySec uint32
+	WithdrawTime    *big.Int
+}, error) {
+	return _EntryPointV07.Contract.Deposits(&_EntryPointV07.CallOpts, arg0)
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x35567e1a.
+//
+// Solidity: function getNonce(address sender, uint192 key) view returns(uint256 nonce)
+func (_EntryPointV07 *EntryPointV07Caller) GetNonce(opts *bind.CallOpts, sender common.Address, key *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _EntryPointV07.contract.Call(opts, &out, "getNonce", sender, key)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x35567e1a.
+//
+// Solidity: function getNonce(address sender, uint192 key) view returns(uint256 nonce)
+func (_EntryPointV07 *EntryPointV07Session) GetNonce(sender common.Address, key *big.Int) (*big.Int, error) {
+	return _EntryPointV07.Contract.GetNonce(&_EntryPointV07.CallOpts, sender, key)
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x35567e1a.
+//
+// Solidity: function getNonce(address sender, uint192 key) view returns(uint256 nonce)
+func (_EntryPointV07 *EntryPointV07CallerSession) GetNonce(sender common.Address, key *big.Int) (*big.Int, error) {
+	return _EntryPointV07.Contract.GetNonce(&_EntryPointV07.CallOpts, sender, key)
+}
+
+// GetUserOpHash is a free data retrieval call binding the contract method 0x22cdde4c.
+//
+// Solidity: function getUserOpHash((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes) userOp) view returns(bytes32)
+func (_EntryPointV07 *EntryPointV07Caller) GetUserOpHash(opts *bind.CallOpts, userOp PackedUserOperation) ([32]byte, error) {
+	var out []interface{}
+	err := _EntryPointV07.contract.Call(opts, &out, "getUserOpHash", userOp)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetUserOpHash is a free data retrieval call binding the contract method 0x22cdde4c.
+//
+// Solidity: function getUserOpHash((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes) userOp) view returns(bytes32)
+func (_EntryPointV07 *EntryPointV07Session) GetUserOpHash(userOp PackedUserOperation) ([32]byte, error) {
+	return _EntryPointV07.Contract.GetUserOpHash(&_EntryPointV07.CallOpts, userOp)
+}
+
+// GetUserOpHash is a free data retrieval call binding the contract method 0x22cdde4c.
+//
+// Solidity: function getUserOpHash((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes) userOp) view returns(bytes32)
+func (_EntryPointV07 *EntryPointV07CallerSession) GetUserOpHash(userOp PackedUserOperation) ([32]byte, error) {
+	return _EntryPointV07.Contract.GetUserOpHash(&_EntryPointV07.CallOpts, userOp)
+}
+
+// NonceSequenceNumber is a free data retrieval call binding the contract method 0x1b2e01b8.
+//
+// Solidity: function nonceSequenceNumber(address , uint192 ) view returns(uint256)
+func (_EntryPointV07 *EntryPointV07Caller) NonceSequenceNumber(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _EntryPointV07.contract.Call(opts, &out, "nonceSequenceNumber", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NonceSequenceNumber is a free data retrieval call binding the contract method 0x1b2e01b8.
+//
+// Solidity: function nonceSequenceNumber(address , uint192 ) view returns(uint256)
+func (_EntryPointV07 *EntryPointV07Session) NonceSequenceNumber(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _EntryPointV07.Contract.NonceSequenceNumber(&_EntryPointV07.CallOpts, arg0, arg1)
+}
+
+// NonceSequenceNumber is a free data retrieval call binding the contract method 0x1b2e01b8.
+//
+// Solidity: function nonceSequenceNumber(address , uint192 ) view returns(uint256)
+func (_EntryPointV07 *EntryPointV07CallerSession) NonceSequenceNumber(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _EntryPointV07.Contract.NonceSequenceNumber(&_EntryPointV07.CallOpts, arg0, arg1)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_EntryPointV07 *EntryPointV07Caller) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+	var out []interface{}
+	err := _EntryPointV07.contract.Call(opts, &out, "supportsInterface", interfaceId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_EntryPointV07 *EntryPointV07Session) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _EntryPointV07.Contract.SupportsInterface(&_EntryPointV07.CallOpts, interfaceId)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_EntryPointV07 *EntryPointV07CallerSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _EntryPointV07.Contract.SupportsInterface(&_EntryPointV07.CallOpts, interfaceId)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x0396cb60.
+//
+// Solidity: function addStake(uint32 unstakeDelaySec) payable returns()
+func (_EntryPointV07 *EntryPointV07Transactor) AddStake(opts *bind.TransactOpts, unstakeDelaySec uint32) (*types.Transaction, error) {
+	return _EntryPointV07.contract.Transact(opts, "addStake", unstakeDelaySec)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x0396cb60.
+//
+// Solidity: function addStake(uint32 unstakeDelaySec) payable returns()
+func (_EntryPointV07 *EntryPointV07Session) AddStake(unstakeDelaySec uint32) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.AddStake(&_EntryPointV07.TransactOpts, unstakeDelaySec)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x0396cb60.
+//
+// Solidity: function addStake(uint32 unstakeDelaySec) payable returns()
+func (_EntryPointV07 *EntryPointV07TransactorSession) AddStake(unstakeDelaySec uint32) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.AddStake(&_EntryPointV07.TransactOpts, unstakeDelaySec)
+}
+
+// DelegateAndRevert is a paid mutator transaction binding the contract method 0x850aaf62.
+//
+// Solidity: function delegateAndRevert(address target, bytes data) returns()
+func (_EntryPointV07 *EntryPointV07Transactor) DelegateAndRevert(opts *bind.TransactOpts, target common.Address, data []byte) (*types.Transaction, error) {
+	return _EntryPointV07.contract.Transact(opts, "delegateAndRevert", target, data)
+}
+
+// DelegateAndRevert is a paid mutator transaction binding the contract method 0x850aaf62.
+//
+// Solidity: function delegateAndRevert(address target, bytes data) returns()
+func (_EntryPointV07 *EntryPointV07Session) DelegateAndRevert(target common.Address, data []byte) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.DelegateAndRevert(&_EntryPointV07.TransactOpts, target, data)
+}
+
+// DelegateAndRevert is a paid mutator transaction binding the contract method 0x850aaf62.
+//
+// Solidity: function delegateAndRevert(address target, bytes data) returns()
+func (_EntryPointV07 *EntryPointV07TransactorSession) DelegateAndRevert(target common.Address, data []byte) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.DelegateAndRevert(&_EntryPointV07.TransactOpts, target, data)
+}
+
+// DepositTo is a paid mutator transaction binding the contract method 0xb760faf9.
+//
+// Solidity: function depositTo(address account) payable returns()
+func (_EntryPointV07 *EntryPointV07Transactor) DepositTo(opts *bind.TransactOpts, account common.Address) (*types.Transaction, error) {
+	return _EntryPointV07.contract.Transact(opts, "depositTo", account)
+}
+
+// DepositTo is a paid mutator transaction binding the contract method 0xb760faf9.
+//
+// Solidity: function depositTo(address account) payable returns()
+func (_EntryPointV07 *EntryPointV07Session) DepositTo(account common.Address) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.DepositTo(&_EntryPointV07.TransactOpts, account)
+}
+
+// DepositTo is a paid mutator transaction binding the contract method 0xb760faf9.
+//
+// Solidity: function depositTo(address account) payable returns()
+func (_EntryPointV07 *EntryPointV07TransactorSession) DepositTo(account common.Address) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.DepositTo(&_EntryPointV07.TransactOpts, account)
+}
+
+// GetSenderAddress is a paid mutator transaction binding the contract method 0x9b249f69.
+//
+// Solidity: function getSenderAddress(bytes initCode) returns()
+func (_EntryPointV07 *EntryPointV07Transactor) GetSenderAddress(opts *bind.TransactOpts, initCode []byte) (*types.Transaction, error) {
+	return _EntryPointV07.contract.Transact(opts, "getSenderAddress", initCode)
+}
+
+// GetSenderAddress is a paid mutator transaction binding the contract method 0x9b249f69.
+//
+// Solidity: function getSenderAddress(bytes initCode) returns()
+func (_EntryPointV07 *EntryPointV07Session) GetSenderAddress(initCode []byte) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.GetSenderAddress(&_EntryPointV07.TransactOpts, initCode)
+}
+
+// GetSenderAddress is a paid mutator transaction binding the contract method 0x9b249f69.
+//
+// Solidity: function getSenderAddress(bytes initCode) returns()
+func (_EntryPointV07 *EntryPointV07TransactorSession) GetSenderAddress(initCode []byte) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.GetSenderAddress(&_EntryPointV07.TransactOpts, initCode)
+}
+
+// HandleOps is a paid mutator transaction binding the contract method 0x765e827f.
+//
+// Solidity: function handleOps((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes)[] ops, address beneficiary) returns()
+func (_EntryPointV07 *EntryPointV07Transactor) HandleOps(opts *bind.TransactOpts, ops []PackedUserOperation, beneficiary common.Address) (*types.Transaction, error) {
+	return _EntryPointV07.contract.Transact(opts, "handleOps", ops, beneficiary)
+}
+
+// HandleOps is a paid mutator transaction binding the contract method 0x765e827f.
+//
+// Solidity: function handleOps((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes)[] ops, address beneficiary) returns()
+func (_EntryPointV07 *EntryPointV07Session) HandleOps(ops []PackedUserOperation, beneficiary common.Address) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.HandleOps(&_EntryPointV07.TransactOpts, ops, beneficiary)
+}
+
+// HandleOps is a paid mutator transaction binding the contract method 0x765e827f.
+//
+// Solidity: function handleOps((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes)[] ops, address beneficiary) returns()
+func (_EntryPointV07 *EntryPointV07TransactorSession) HandleOps(ops []PackedUserOperation, beneficiary common.Address) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.HandleOps(&_EntryPointV07.TransactOpts, ops, beneficiary)
+}
+
+// IncrementNonce is a paid mutator transaction binding the contract method 0x0bd28e3b.
+//
+// Solidity: function incrementNonce(uint192 key) returns()
+func (_EntryPointV07 *EntryPointV07Transactor) IncrementNonce(opts *bind.TransactOpts, key *big.Int) (*types.Transaction, error) {
+	return _EntryPointV07.contract.Transact(opts, "incrementNonce", key)
+}
+
+// IncrementNonce is a paid mutator transaction binding the contract method 0x0bd28e3b.
+//
+// Solidity: function incrementNonce(uint192 key) returns()
+func (_EntryPointV07 *EntryPointV07Session) IncrementNonce(key *big.Int) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.IncrementNonce(&_EntryPointV07.TransactOpts, key)
+}
+
+// IncrementNonce is a paid mutator transaction binding the contract method 0x0bd28e3b.
+//
+// Solidity: function incrementNonce(uint192 key) returns()
+func (_EntryPointV07 *EntryPointV07TransactorSession) IncrementNonce(key *big.Int) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.IncrementNonce(&_EntryPointV07.TransactOpts, key)
+}
+
+// UnlockStake is a paid mutator transaction binding the contract method 0xbb9fe6bf.
+//
+// Solidity: function unlockStake() returns()
+func (_EntryPointV07 *EntryPointV07Transactor) UnlockStake(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EntryPointV07.contract.Transact(opts, "unlockStake")
+}
+
+// UnlockStake is a paid mutator transaction binding the contract method 0xbb9fe6bf.
+//
+// Solidity: function unlockStake() returns()
+func (_EntryPointV07 *EntryPointV07Session) UnlockStake() (*types.Transaction, error) {
+	return _EntryPointV07.Contract.UnlockStake(&_EntryPointV07.TransactOpts)
+}
+
+// UnlockStake is a paid mutator transaction binding the contract method 0xbb9fe6bf.
+//
+// Solidity: function unlockStake() returns()
+func (_EntryPointV07 *EntryPointV07TransactorSession) UnlockStake() (*types.Transaction, error) {
+	return _EntryPointV07.Contract.UnlockStake(&_EntryPointV07.TransactOpts)
+}
+
+// WithdrawStake is a paid mutator transaction binding the contract method 0xc23a5cea.
+//
+// Solidity: function withdrawStake(address withdrawAddress) returns()
+func (_EntryPointV07 *EntryPointV07Transactor) WithdrawStake(opts *bind.TransactOpts, withdrawAddress common.Address) (*types.Transaction, error) {
+	return _EntryPointV07.contract.Transact(opts, "withdrawStake", withdrawAddress)
+}
+
+// WithdrawStake is a paid mutator transaction binding the contract method 0xc23a5cea.
+//
+// Solidity: function withdrawStake(address withdrawAddress) returns()
+func (_EntryPointV07 *EntryPointV07Session) WithdrawStake(withdrawAddress common.Address) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.WithdrawStake(&_EntryPointV07.TransactOpts, withdrawAddress)
+}
+
+// WithdrawStake is a paid mutator transaction binding the contract method 0xc23a5cea.
+//
+// Solidity: function withdrawStake(address withdrawAddress) returns()
+func (_EntryPointV07 *EntryPointV07TransactorSession) WithdrawStake(withdrawAddress common.Address) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.WithdrawStake(&_EntryPointV07.TransactOpts, withdrawAddress)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0x205c2878.
+//
+// Solidity: function withdrawTo(address withdrawAddress, uint256 withdrawAmount) returns()
+func (_EntryPointV07 *EntryPointV07Transactor) WithdrawTo(opts *bind.TransactOpts, withdrawAddress common.Address, withdrawAmount *big.Int) (*types.Transaction, error) {
+	return _EntryPointV07.contract.Transact(opts, "withdrawTo", withdrawAddress, withdrawAmount)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0x205c2878.
+//
+// Solidity: function withdrawTo(address withdrawAddress, uint256 withdrawAmount) returns()
+func (_EntryPointV07 *EntryPointV07Session) WithdrawTo(withdrawAddress common.Address, withdrawAmount *big.Int) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.WithdrawTo(&_EntryPointV07.TransactOpts, withdrawAddress, withdrawAmount)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0x205c2878.
+//
+// Solidity: function withdrawTo(address withdrawAddress, uint256 withdrawAmount) returns()
+func (_EntryPointV07 *EntryPointV07TransactorSession) WithdrawTo(withdrawAddress common.Address, withdrawAmount *big.Int) (*types.Transaction, error) {
+	return _EntryPointV07.Contract.WithdrawTo(&_EntryPointV07.TransactOpts, withdrawAddress, withdrawAmount)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_EntryPointV07 *EntryPointV07Transactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EntryPointV07.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_EntryPointV07 *EntryPointV07Session) Receive() (*types.Transaction, error) {
+	return _EntryPointV07.Contract.Receive(&_EntryPointV07.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_EntryPointV07 *EntryPointV07TransactorSession) Receive() (*types.Transaction, error) {
+	return _EntryPointV07.Contract.Receive(&_EntryPointV07.TransactOpts)
+}
+
+// EntryPointV07AccountDeployedIterator is returned from FilterAccountDeployed and is used to iterate over the raw logs and unpacked data for AccountDeployed events raised by the EntryPointV07 contract.
+type EntryPointV07AccountDeployedIterator struct {
+	Event *EntryPointV07AccountDeployed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07AccountDeployedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07AccountDeployed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07AccountDeployed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07AccountDeployedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07AccountDeployedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07AccountDeployed represents a AccountDeployed event raised by the EntryPointV07 contract.
+type EntryPointV07AccountDeployed struct {
+	UserOpHash [32]byte
+	Sender     common.Address
+	Factory    common.Address
+	Paymaster  common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterAccountDeployed is a free log retrieval operation binding the contract event 0xd51a9c61267aa6196961883ecf5ff2da6619c37dac0fa92122513fb32c032d2d.
+//
+// Solidity: event AccountDeployed(bytes32 indexed userOpHash, address indexed sender, address factory, address paymaster)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterAccountDeployed(opts *bind.FilterOpts, userOpHash [][32]byte, sender []common.Address) (*EntryPointV07AccountDeployedIterator, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "AccountDeployed", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07AccountDeployedIterator{contract: _EntryPointV07.contract, event: "AccountDeployed", logs: logs, sub: sub}, nil
+}
+
+// WatchAccountDeployed is a free log subscription operation binding the contract event 0xd51a9c61267aa6196961883ecf5ff2da6619c37dac0fa92122513fb32c032d2d.
+//
+// Solidity: event AccountDeployed(bytes32 indexed userOpHash, address indexed sender, address factory, address paymaster)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchAccountDeployed(opts *bind.WatchOpts, sink chan<- *EntryPointV07AccountDeployed, userOpHash [][32]byte, sender []common.Address) (event.Subscription, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "AccountDeployed", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07AccountDeployed)
+				if err := _EntryPointV07.contract.UnpackLog(event, "AccountDeployed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAccountDeployed is a log parse operation binding the contract event 0xd51a9c61267aa6196961883ecf5ff2da6619c37dac0fa92122513fb32c032d2d.
+//
+// Solidity: event AccountDeployed(bytes32 indexed userOpHash, address indexed sender, address factory, address paymaster)
+func (_EntryPointV07 *EntryPointV07Filterer) ParseAccountDeployed(log types.Log) (*EntryPointV07AccountDeployed, error) {
+	event := new(EntryPointV07AccountDeployed)
+	if err := _EntryPointV07.contract.UnpackLog(event, "AccountDeployed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07BeforeExecutionIterator is returned from FilterBeforeExecution and is used to iterate over the raw logs and unpacked data for BeforeExecution events raised by the EntryPointV07 contract.
+type EntryPointV07BeforeExecutionIterator struct {
+	Event *EntryPointV07BeforeExecution // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07BeforeExecutionIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07BeforeExecution)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07BeforeExecution)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07BeforeExecutionIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07BeforeExecutionIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07BeforeExecution represents a BeforeExecution event raised by the EntryPointV07 contract.
+type EntryPointV07BeforeExecution struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterBeforeExecution is a free log retrieval operation binding the contract event 0xbb47ee3e183a558b1a2ff0874b079f3fc5478b7454eacf2bfc5af2ff5878f972.
+//
+// Solidity: event BeforeExecution()
+func (_EntryPointV07 *EntryPointV07Filterer) FilterBeforeExecution(opts *bind.FilterOpts) (*EntryPointV07BeforeExecutionIterator, error) {
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "BeforeExecution")
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07BeforeExecutionIterator{contract: _EntryPointV07.contract, event: "BeforeExecution", logs: logs, sub: sub}, nil
+}
+
+// WatchBeforeExecution is a free log subscription operation binding the contract event 0xbb47ee3e183a558b1a2ff0874b079f3fc5478b7454eacf2bfc5af2ff5878f972.
+//
+// Solidity: event BeforeExecution()
+func (_EntryPointV07 *EntryPointV07Filterer) WatchBeforeExecution(opts *bind.WatchOpts, sink chan<- *EntryPointV07BeforeExecution) (event.Subscription, error) {
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "BeforeExecution")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07BeforeExecution)
+				if err := _EntryPointV07.contract.UnpackLog(event, "BeforeExecution", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBeforeExecution is a log parse operation binding the contract event 0xbb47ee3e183a558b1a2ff0874b079f3fc5478b7454eacf2bfc5af2ff5878f972.
+//
+// Solidity: event BeforeExecution()
+func (_EntryPointV07 *EntryPointV07Filterer) ParseBeforeExecution(log types.Log) (*EntryPointV07BeforeExecution, error) {
+	event := new(EntryPointV07BeforeExecution)
+	if err := _EntryPointV07.contract.UnpackLog(event, "BeforeExecution", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07DepositedIterator is returned from FilterDeposited and is used to iterate over the raw logs and unpacked data for Deposited events raised by the EntryPointV07 contract.
+type EntryPointV07DepositedIterator struct {
+	Event *EntryPointV07Deposited // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07DepositedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07Deposited)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07Deposited)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07DepositedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07DepositedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07Deposited represents a Deposited event raised by the EntryPointV07 contract.
+type EntryPointV07Deposited struct {
+	Account      common.Address
+	TotalDeposit *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterDeposited is a free log retrieval operation binding the contract event 0x2da466a7b24304f47e87fa2e1e5a81b9831ce54fec19055ce277ca2f39ba42c4.
+//
+// Solidity: event Deposited(address indexed account, uint256 totalDeposit)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterDeposited(opts *bind.FilterOpts, account []common.Address) (*EntryPointV07DepositedIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "Deposited", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07DepositedIterator{contract: _EntryPointV07.contract, event: "Deposited", logs: logs, sub: sub}, nil
+}
+
+// WatchDeposited is a free log subscription operation binding the contract event 0x2da466a7b24304f47e87fa2e1e5a81b9831ce54fec19055ce277ca2f39ba42c4.
+//
+// Solidity: event Deposited(address indexed account, uint256 totalDeposit)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchDeposited(opts *bind.WatchOpts, sink chan<- *EntryPointV07Deposited, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "Deposited", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07Deposited)
+				if err := _EntryPointV07.contract.UnpackLog(event, "Deposited", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDeposited is a log parse operation binding the contract event 0x2da466a7b24304f47e87fa2e1e5a81b9831ce54fec19055ce277ca2f39ba42c4.
+//
+// Solidity: event Deposited(address indexed account, uint256 totalDeposit)
+func (_EntryPointV07 *EntryPointV07Filterer) ParseDeposited(log types.Log) (*EntryPointV07Deposited, error) {
+	event := new(EntryPointV07Deposited)
+	if err := _EntryPointV07.contract.UnpackLog(event, "Deposited", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07PostOpRevertReasonIterator is returned from FilterPostOpRevertReason and is used to iterate over the raw logs and unpacked data for PostOpRevertReason events raised by the EntryPointV07 contract.
+type EntryPointV07PostOpRevertReasonIterator struct {
+	Event *EntryPointV07PostOpRevertReason // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07PostOpRevertReasonIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07PostOpRevertReason)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07PostOpRevertReason)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07PostOpRevertReasonIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07PostOpRevertReasonIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07PostOpRevertReason represents a PostOpRevertReason event raised by the EntryPointV07 contract.
+type EntryPointV07PostOpRevertReason struct {
+	UserOpHash   [32]byte
+	Sender       common.Address
+	Nonce        *big.Int
+	RevertReason []byte
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterPostOpRevertReason is a free log retrieval operation binding the contract event 0xf62676f440ff169a3a9afdbf812e89e7f95975ee8e5c31214ffdef631c5f4792.
+//
+// Solidity: event PostOpRevertReason(bytes32 indexed userOpHash, address indexed sender, uint256 nonce, bytes revertReason)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterPostOpRevertReason(opts *bind.FilterOpts, userOpHash [][32]byte, sender []common.Address) (*EntryPointV07PostOpRevertReasonIterator, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "PostOpRevertReason", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07PostOpRevertReasonIterator{contract: _EntryPointV07.contract, event: "PostOpRevertReason", logs: logs, sub: sub}, nil
+}
+
+// WatchPostOpRevertReason is a free log subscription operation binding the contract event 0xf62676f440ff169a3a9afdbf812e89e7f95975ee8e5c31214ffdef631c5f4792.
+//
+// Solidity: event PostOpRevertReason(bytes32 indexed userOpHash, address indexed sender, uint256 nonce, bytes revertReason)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchPostOpRevertReason(opts *bind.WatchOpts, sink chan<- *EntryPointV07PostOpRevertReason, userOpHash [][32]byte, sender []common.Address) (event.Subscription, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "PostOpRevertReason", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07PostOpRevertReason)
+				if err := _EntryPointV07.contract.UnpackLog(event, "PostOpRevertReason", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePostOpRevertReason is a log parse operation binding the contract event 0xf62676f440ff169a3a9afdbf812e89e7f95975ee8e5c31214ffdef631c5f4792.
+//
+// Solidity: event PostOpRevertReason(bytes32 indexed userOpHash, address indexed sender, uint256 nonce, bytes revertReason)
+func (_EntryPointV07 *EntryPointV07Filterer) ParsePostOpRevertReason(log types.Log) (*EntryPointV07PostOpRevertReason, error) {
+	event := new(EntryPointV07PostOpRevertReason)
+	if err := _EntryPointV07.contract.UnpackLog(event, "PostOpRevertReason", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07SignatureAggregatorChangedIterator is returned from FilterSignatureAggregatorChanged and is used to iterate over the raw logs and unpacked data for SignatureAggregatorChanged events raised by the EntryPointV07 contract.
+type EntryPointV07SignatureAggregatorChangedIterator struct {
+	Event *EntryPointV07SignatureAggregatorChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07SignatureAggregatorChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07SignatureAggregatorChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07SignatureAggregatorChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07SignatureAggregatorChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07SignatureAggregatorChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07SignatureAggregatorChanged represents a SignatureAggregatorChanged event raised by the EntryPointV07 contract.
+type EntryPointV07SignatureAggregatorChanged struct {
+	Aggregator common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterSignatureAggregatorChanged is a free log retrieval operation binding the contract event 0x575ff3acadd5ab348fe1855e217e0f3678f8d767d7494c9f9fefbee2e17cca4d.
+//
+// Solidity: event SignatureAggregatorChanged(address indexed aggregator)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterSignatureAggregatorChanged(opts *bind.FilterOpts, aggregator []common.Address) (*EntryPointV07SignatureAggregatorChangedIterator, error) {
+
+	var aggregatorRule []interface{}
+	for _, aggregatorItem := range aggregator {
+		aggregatorRule = append(aggregatorRule, aggregatorItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "SignatureAggregatorChanged", aggregatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07SignatureAggregatorChangedIterator{contract: _EntryPointV07.contract, event: "SignatureAggregatorChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchSignatureAggregatorChanged is a free log subscription operation binding the contract event 0x575ff3acadd5ab348fe1855e217e0f3678f8d767d7494c9f9fefbee2e17cca4d.
+//
+// Solidity: event SignatureAggregatorChanged(address indexed aggregator)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchSignatureAggregatorChanged(opts *bind.WatchOpts, sink chan<- *EntryPointV07SignatureAggregatorChanged, aggregator []common.Address) (event.Subscription, error) {
+
+	var aggregatorRule []interface{}
+	for _, aggregatorItem := range aggregator {
+		aggregatorRule = append(aggregatorRule, aggregatorItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "SignatureAggregatorChanged", aggregatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07SignatureAggregatorChanged)
+				if err := _EntryPointV07.contract.UnpackLog(event, "SignatureAggregatorChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSignatureAggregatorChanged is a log parse operation binding the contract event 0x575ff3acadd5ab348fe1855e217e0f3678f8d767d7494c9f9fefbee2e17cca4d.
+//
+// Solidity: event SignatureAggregatorChanged(address indexed aggregator)
+func (_EntryPointV07 *EntryPointV07Filterer) ParseSignatureAggregatorChanged(log types.Log) (*EntryPointV07SignatureAggregatorChanged, error) {
+	event := new(EntryPointV07SignatureAggregatorChanged)
+	if err := _EntryPointV07.contract.UnpackLog(event, "SignatureAggregatorChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07StakeLockedIterator is returned from FilterStakeLocked and is used to iterate over the raw logs and unpacked data for StakeLocked events raised by the EntryPointV07 contract.
+type EntryPointV07StakeLockedIterator struct {
+	Event *EntryPointV07StakeLocked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07StakeLockedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07StakeLocked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07StakeLocked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07StakeLockedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07StakeLockedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07StakeLocked represents a StakeLocked event raised by the EntryPointV07 contract.
+type EntryPointV07StakeLocked struct {
+	Account         common.Address
+	TotalStaked     *big.Int
+	UnstakeDelaySec *big.Int
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterStakeLocked is a free log retrieval operation binding the contract event 0xa5ae833d0bb1dcd632d98a8b70973e8516812898e19bf27b70071ebc8dc52c01.
+//
+// Solidity: event StakeLocked(address indexed account, uint256 totalStaked, uint256 unstakeDelaySec)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterStakeLocked(opts *bind.FilterOpts, account []common.Address) (*EntryPointV07StakeLockedIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "StakeLocked", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07StakeLockedIterator{contract: _EntryPointV07.contract, event: "StakeLocked", logs: logs, sub: sub}, nil
+}
+
+// WatchStakeLocked is a free log subscription operation binding the contract event 0xa5ae833d0bb1dcd632d98a8b70973e8516812898e19bf27b70071ebc8dc52c01.
+//
+// Solidity: event StakeLocked(address indexed account, uint256 totalStaked, uint256 unstakeDelaySec)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchStakeLocked(opts *bind.WatchOpts, sink chan<- *EntryPointV07StakeLocked, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "StakeLocked", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07StakeLocked)
+				if err := _EntryPointV07.contract.UnpackLog(event, "StakeLocked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStakeLocked is a log parse operation binding the contract event 0xa5ae833d0bb1dcd632d98a8b70973e8516812898e19bf27b70071ebc8dc52c01.
+//
+// Solidity: event StakeLocked(address indexed account, uint256 totalStaked, uint256 unstakeDelaySec)
+func (_EntryPointV07 *EntryPointV07Filterer) ParseStakeLocked(log types.Log) (*EntryPointV07StakeLocked, error) {
+	event := new(EntryPointV07StakeLocked)
+	if err := _EntryPointV07.contract.UnpackLog(event, "StakeLocked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07StakeUnlockedIterator is returned from FilterStakeUnlocked and is used to iterate over the raw logs and unpacked data for StakeUnlocked events raised by the EntryPointV07 contract.
+type EntryPointV07StakeUnlockedIterator struct {
+	Event *EntryPointV07StakeUnlocked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07StakeUnlockedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07StakeUnlocked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07StakeUnlocked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07StakeUnlockedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07StakeUnlockedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07StakeUnlocked represents a StakeUnlocked event raised by the EntryPointV07 contract.
+type EntryPointV07StakeUnlocked struct {
+	Account      common.Address
+	WithdrawTime *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterStakeUnlocked is a free log retrieval operation binding the contract event 0xfa9b3c14cc825c412c9ed81b3ba365a5b459439403f18829e572ed53a4180f0a.
+//
+// Solidity: event StakeUnlocked(address indexed account, uint256 withdrawTime)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterStakeUnlocked(opts *bind.FilterOpts, account []common.Address) (*EntryPointV07StakeUnlockedIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "StakeUnlocked", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07StakeUnlockedIterator{contract: _EntryPointV07.contract, event: "StakeUnlocked", logs: logs, sub: sub}, nil
+}
+
+// WatchStakeUnlocked is a free log subscription operation binding the contract event 0xfa9b3c14cc825c412c9ed81b3ba365a5b459439403f18829e572ed53a4180f0a.
+//
+// Solidity: event StakeUnlocked(address indexed account, uint256 withdrawTime)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchStakeUnlocked(opts *bind.WatchOpts, sink chan<- *EntryPointV07StakeUnlocked, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "StakeUnlocked", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07StakeUnlocked)
+				if err := _EntryPointV07.contract.UnpackLog(event, "StakeUnlocked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStakeUnlocked is a log parse operation binding the contract event 0xfa9b3c14cc825c412c9ed81b3ba365a5b459439403f18829e572ed53a4180f0a.
+//
+// Solidity: event StakeUnlocked(address indexed account, uint256 withdrawTime)
+func (_EntryPointV07 *EntryPointV07Filterer) ParseStakeUnlocked(log types.Log) (*EntryPointV07StakeUnlocked, error) {
+	event := new(EntryPointV07StakeUnlocked)
+	if err := _EntryPointV07.contract.UnpackLog(event, "StakeUnlocked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07StakeWithdrawnIterator is returned from FilterStakeWithdrawn and is used to iterate over the raw logs and unpacked data for StakeWithdrawn events raised by the EntryPointV07 contract.
+type EntryPointV07StakeWithdrawnIterator struct {
+	Event *EntryPointV07StakeWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07StakeWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07StakeWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07StakeWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07StakeWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07StakeWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07StakeWithdrawn represents a StakeWithdrawn event raised by the EntryPointV07 contract.
+type EntryPointV07StakeWithdrawn struct {
+	Account         common.Address
+	WithdrawAddress common.Address
+	Amount          *big.Int
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterStakeWithdrawn is a free log retrieval operation binding the contract event 0xb7c918e0e249f999e965cafeb6c664271b3f4317d296461500e71da39f0cbda3.
+//
+// Solidity: event StakeWithdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterStakeWithdrawn(opts *bind.FilterOpts, account []common.Address) (*EntryPointV07StakeWithdrawnIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "StakeWithdrawn", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07StakeWithdrawnIterator{contract: _EntryPointV07.contract, event: "StakeWithdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchStakeWithdrawn is a free log subscription operation binding the contract event 0xb7c918e0e249f999e965cafeb6c664271b3f4317d296461500e71da39f0cbda3.
+//
+// Solidity: event StakeWithdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchStakeWithdrawn(opts *bind.WatchOpts, sink chan<- *EntryPointV07StakeWithdrawn, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "StakeWithdrawn", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07StakeWithdrawn)
+				if err := _EntryPointV07.contract.UnpackLog(event, "StakeWithdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStakeWithdrawn is a log parse operation binding the contract event 0xb7c918e0e249f999e965cafeb6c664271b3f4317d296461500e71da39f0cbda3.
+//
+// Solidity: event StakeWithdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV07 *EntryPointV07Filterer) ParseStakeWithdrawn(log types.Log) (*EntryPointV07StakeWithdrawn, error) {
+	event := new(EntryPointV07StakeWithdrawn)
+	if err := _EntryPointV07.contract.UnpackLog(event, "StakeWithdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07UserOperationEventIterator is returned from FilterUserOperationEvent and is used to iterate over the raw logs and unpacked data for UserOperationEvent events raised by the EntryPointV07 contract.
+type EntryPointV07UserOperationEventIterator struct {
+	Event *EntryPointV07UserOperationEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07UserOperationEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07UserOperationEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07UserOperationEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07UserOperationEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07UserOperationEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07UserOperationEvent represents a UserOperationEvent event raised by the EntryPointV07 contract.
+type EntryPointV07UserOperationEvent struct {
+	UserOpHash    [32]byte
+	Sender        common.Address
+	Paymaster     common.Address
+	Nonce         *big.Int
+	Success       bool
+	ActualGasCost *big.Int
+	ActualGasUsed *big.Int
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterUserOperationEvent is a free log retrieval operation binding the contract event 0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f.
+//
+// Solidity: event UserOperationEvent(bytes32 indexed userOpHash, address indexed sender, address indexed paymaster, uint256 nonce, bool success, uint256 actualGasCost, uint256 actualGasUsed)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterUserOperationEvent(opts *bind.FilterOpts, userOpHash [][32]byte, sender []common.Address, paymaster []common.Address) (*EntryPointV07UserOperationEventIterator, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var paymasterRule []interface{}
+	for _, paymasterItem := range paymaster {
+		paymasterRule = append(paymasterRule, paymasterItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "UserOperationEvent", userOpHashRule, senderRule, paymasterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07UserOperationEventIterator{contract: _EntryPointV07.contract, event: "UserOperationEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchUserOperationEvent is a free log subscription operation binding the contract event 0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f.
+//
+// Solidity: event UserOperationEvent(bytes32 indexed userOpHash, address indexed sender, address indexed paymaster, uint256 nonce, bool success, uint256 actualGasCost, uint256 actualGasUsed)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchUserOperationEvent(opts *bind.WatchOpts, sink chan<- *EntryPointV07UserOperationEvent, userOpHash [][32]byte, sender []common.Address, paymaster []common.Address) (event.Subscription, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var paymasterRule []interface{}
+	for _, paymasterItem := range paymaster {
+		paymasterRule = append(paymasterRule, paymasterItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "UserOperationEvent", userOpHashRule, senderRule, paymasterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07UserOperationEvent)
+				if err := _EntryPointV07.contract.UnpackLog(event, "UserOperationEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUserOperationEvent is a log parse operation binding the contract event 0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f.
+//
+// Solidity: event UserOperationEvent(bytes32 indexed userOpHash, address indexed sender, address indexed paymaster, uint256 nonce, bool success, uint256 actualGasCost, uint256 actualGasUsed)
+func (_EntryPointV07 *EntryPointV07Filterer) ParseUserOperationEvent(log types.Log) (*EntryPointV07UserOperationEvent, error) {
+	event := new(EntryPointV07UserOperationEvent)
+	if err := _EntryPointV07.contract.UnpackLog(event, "UserOperationEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07UserOperationPrefundTooLowIterator is returned from FilterUserOperationPrefundTooLow and is used to iterate over the raw logs and unpacked data for UserOperationPrefundTooLow events raised by the EntryPointV07 contract.
+type EntryPointV07UserOperationPrefundTooLowIterator struct {
+	Event *EntryPointV07UserOperationPrefundTooLow // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07UserOperationPrefundTooLowIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07UserOperationPrefundTooLow)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07UserOperationPrefundTooLow)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07UserOperationPrefundTooLowIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07UserOperationPrefundTooLowIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07UserOperationPrefundTooLow represents a UserOperationPrefundTooLow event raised by the EntryPointV07 contract.
+type EntryPointV07UserOperationPrefundTooLow struct {
+	UserOpHash [32]byte
+	Sender     common.Address
+	Nonce      *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterUserOperationPrefundTooLow is a free log retrieval operation binding the contract event 0x67b4fa9642f42120bf031f3051d1824b0fe25627945b27b8a6a65d5761d5482e.
+//
+// Solidity: event UserOperationPrefundTooLow(bytes32 indexed userOpHash, address indexed sender, uint256 nonce)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterUserOperationPrefundTooLow(opts *bind.FilterOpts, userOpHash [][32]byte, sender []common.Address) (*EntryPointV07UserOperationPrefundTooLowIterator, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "UserOperationPrefundTooLow", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07UserOperationPrefundTooLowIterator{contract: _EntryPointV07.contract, event: "UserOperationPrefundTooLow", logs: logs, sub: sub}, nil
+}
+
+// WatchUserOperationPrefundTooLow is a free log subscription operation binding the contract event 0x67b4fa9642f42120bf031f3051d1824b0fe25627945b27b8a6a65d5761d5482e.
+//
+// Solidity: event UserOperationPrefundTooLow(bytes32 indexed userOpHash, address indexed sender, uint256 nonce)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchUserOperationPrefundTooLow(opts *bind.WatchOpts, sink chan<- *EntryPointV07UserOperationPrefundTooLow, userOpHash [][32]byte, sender []common.Address) (event.Subscription, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "UserOperationPrefundTooLow", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07UserOperationPrefundTooLow)
+				if err := _EntryPointV07.contract.UnpackLog(event, "UserOperationPrefundTooLow", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUserOperationPrefundTooLow is a log parse operation binding the contract event 0x67b4fa9642f42120bf031f3051d1824b0fe25627945b27b8a6a65d5761d5482e.
+//
+// Solidity: event UserOperationPrefundTooLow(bytes32 indexed userOpHash, address indexed sender, uint256 nonce)
+func (_EntryPointV07 *EntryPointV07Filterer) ParseUserOperationPrefundTooLow(log types.Log) (*EntryPointV07UserOperationPrefundTooLow, error) {
+	event := new(EntryPointV07UserOperationPrefundTooLow)
+	if err := _EntryPointV07.contract.UnpackLog(event, "UserOperationPrefundTooLow", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07UserOperationRevertReasonIterator is returned from FilterUserOperationRevertReason and is used to iterate over the raw logs and unpacked data for UserOperationRevertReason events raised by the EntryPointV07 contract.
+type EntryPointV07UserOperationRevertReasonIterator struct {
+	Event *EntryPointV07UserOperationRevertReason // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07UserOperationRevertReasonIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07UserOperationRevertReason)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07UserOperationRevertReason)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07UserOperationRevertReasonIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07UserOperationRevertReasonIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07UserOperationRevertReason represents a UserOperationRevertReason event raised by the EntryPointV07 contract.
+type EntryPointV07UserOperationRevertReason struct {
+	UserOpHash   [32]byte
+	Sender       common.Address
+	Nonce        *big.Int
+	RevertReason []byte
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterUserOperationRevertReason is a free log retrieval operation binding the contract event 0x1c4fada7374c0a9ee8841fc38afe82932dc0f8e69012e927f061a8bae611a201.
+//
+// Solidity: event UserOperationRevertReason(bytes32 indexed userOpHash, address indexed sender, uint256 nonce, bytes revertReason)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterUserOperationRevertReason(opts *bind.FilterOpts, userOpHash [][32]byte, sender []common.Address) (*EntryPointV07UserOperationRevertReasonIterator, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "UserOperationRevertReason", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07UserOperationRevertReasonIterator{contract: _EntryPointV07.contract, event: "UserOperationRevertReason", logs: logs, sub: sub}, nil
+}
+
+// WatchUserOperationRevertReason is a free log subscription operation binding the contract event 0x1c4fada7374c0a9ee8841fc38afe82932dc0f8e69012e927f061a8bae611a201.
+//
+// Solidity: event UserOperationRevertReason(bytes32 indexed userOpHash, address indexed sender, uint256 nonce, bytes revertReason)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchUserOperationRevertReason(opts *bind.WatchOpts, sink chan<- *EntryPointV07UserOperationRevertReason, userOpHash [][32]byte, sender []common.Address) (event.Subscription, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "UserOperationRevertReason", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07UserOperationRevertReason)
+				if err := _EntryPointV07.contract.UnpackLog(event, "UserOperationRevertReason", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUserOperationRevertReason is a log parse operation binding the contract event 0x1c4fada7374c0a9ee8841fc38afe82932dc0f8e69012e927f061a8bae611a201.
+//
+// Solidity: event UserOperationRevertReason(bytes32 indexed userOpHash, address indexed sender, uint256 nonce, bytes revertReason)
+func (_EntryPointV07 *EntryPointV07Filterer) ParseUserOperationRevertReason(log types.Log) (*EntryPointV07UserOperationRevertReason, error) {
+	event := new(EntryPointV07UserOperationRevertReason)
+	if err := _EntryPointV07.contract.UnpackLog(event, "UserOperationRevertReason", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV07WithdrawnIterator is returned from FilterWithdrawn and is used to iterate over the raw logs and unpacked data for Withdrawn events raised by the EntryPointV07 contract.
+type EntryPointV07WithdrawnIterator struct {
+	Event *EntryPointV07Withdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV07WithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV07Withdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV07Withdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV07WithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV07WithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV07Withdrawn represents a Withdrawn event raised by the EntryPointV07 contract.
+type EntryPointV07Withdrawn struct {
+	Account         common.Address
+	WithdrawAddress common.Address
+	Amount          *big.Int
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawn is a free log retrieval operation binding the contract event 0xd1c19fbcd4551a5edfb66d43d2e337c04837afda3482b42bdf569a8fccdae5fb.
+//
+// Solidity: event Withdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV07 *EntryPointV07Filterer) FilterWithdrawn(opts *bind.FilterOpts, account []common.Address) (*EntryPointV07WithdrawnIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.FilterLogs(opts, "Withdrawn", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV07WithdrawnIterator{contract: _EntryPointV07.contract, event: "Withdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawn is a free log subscription operation binding the contract event 0xd1c19fbcd4551a5edfb66d43d2e337c04837afda3482b42bdf569a8fccdae5fb.
+//
+// Solidity: event Withdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV07 *EntryPointV07Filterer) WatchWithdrawn(opts *bind.WatchOpts, sink chan<- *EntryPointV07Withdrawn, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV07.contract.WatchLogs(opts, "Withdrawn", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV07Withdrawn)
+				if err := _EntryPointV07.contract.UnpackLog(event, "Withdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawn is a log parse operation binding the contract event 0xd1c19fbcd4551a5edfb66d43d2e337c04837afda3482b42bdf569a8fccdae5fb.
+//
+// Solidity: event Withdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV07 *EntryPointV07Filterer) ParseWithdrawn(log types.Log) (*EntryPointV07Withdrawn, error) {
+	event := new(EntryPointV07Withdrawn)
+	if err := _EntryPointV07.contract.UnpackLog(event, "Withdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
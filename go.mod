@@ -2,7 +2,10 @@ module github.com/pavankpdev/goaa
 
 go 1.21
 
-require github.com/ethereum/go-ethereum v1.13.2
+require (
+	github.com/ethereum/go-ethereum v1.13.2
+	github.com/tyler-smith/go-bip39 v1.1.0
+)
 
 require (
 	github.com/DataDog/zstd v1.4.5 // indirect
@@ -76,7 +79,6 @@ require (
 	github.com/syndtr/goleveldb v1.0.1-0.20210819022825-2ae1ddf74ef7 // indirect
 	github.com/tklauser/go-sysconf v0.3.12 // indirect
 	github.com/tklauser/numcpus v0.6.1 // indirect
-	github.com/tyler-smith/go-bip39 v1.1.0 // indirect
 	github.com/urfave/cli/v2 v2.25.7 // indirect
 	github.com/xrash/smetrics v0.0.0-20201216005158-039620a65673 // indirect
 	golang.org/x/crypto v0.12.0 // indirect
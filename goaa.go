@@ -2,114 +2,290 @@ package goaa
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pavankpdev/goaa/bundler"
 	entrypoint "github.com/pavankpdev/goaa/gen"
 	factory "github.com/pavankpdev/goaa/gen"
-	"io"
 	"math/big"
 	"net/http"
-	"strconv"
-	"strings"
 )
 
 // NewSmartAccountProvider creates a new instance of SmartAccountProvider with the provided parameters.
 // It initializes the Ethereum client, owner's address, and the smart account factory contract.
 func NewSmartAccountProvider(params SmartAccountProviderParams) (*SmartAccountProvider, error) {
-	client, err := createEthClient(params.RPC)
+	return NewSmartAccountProviderContext(context.Background(), params)
+}
+
+// NewSmartAccountProviderContext is NewSmartAccountProvider with a context bounding the
+// node and bundler requests made during setup.
+func NewSmartAccountProviderContext(ctx context.Context, params SmartAccountProviderParams) (*SmartAccountProvider, error) {
+	client, err := createEthClient(ctx, params.RPC, params.HTTPClient)
 	if err != nil {
 		return nil, err
 	}
 
-	owner, err := privateKeyToAddress(params.OwnerPrivateKey[2:])
+	signer := params.Signer
+	if signer == nil {
+		if signer, err = NewPrivateKeySigner(params.OwnerPrivateKey); err != nil {
+			return nil, err
+		}
+	}
+	owner := signer.Address()
+
+	chainID, err := client.ChainID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	fac, err := factory.NewFactory(common.HexToAddress(params.SmartAccountFactoryAddress), client)
+	chain, err := resolveChain(params.Chain, chainID)
 	if err != nil {
 		return nil, err
 	}
 
-	ep, err := entrypoint.NewEntryPoint(common.HexToAddress(params.EntryPointAddress), client)
+	bc, supported, err := createBundlerClient(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
+	configuredEntryPoint := params.EntryPointAddress
+	if configuredEntryPoint == "" && chain != nil && params.EntryPointVersion != "" {
+		configuredEntryPoint = chain.EntryPoint(params.EntryPointVersion).Hex()
+	}
+
+	entryPointAddress, err := resolveEntryPoint(configuredEntryPoint, supported)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := detectEntryPointVersion(ctx, client, entryPointAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.EntryPointVersion != "" && params.EntryPointVersion != version {
+		return nil, fmt.Errorf("entry point %s is v%s, not the configured v%s", entryPointAddress, version, params.EntryPointVersion)
+	}
+
+	ep, err := entrypoint.NewEntryPoint(entryPointAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var epV07 *entrypoint.EntryPointV07
+	if version == EntryPointV07 {
+		if epV07, err = entrypoint.NewEntryPointV07(entryPointAddress, client); err != nil {
+			return nil, err
+		}
+	}
+
+	paymaster := params.Paymaster
+	if bound, ok := paymaster.(entryPointBound); ok {
+		if paymaster, err = bound.forEntryPoint(version); err != nil {
+			return nil, err
+		}
+	}
+
+	factoryAddress := params.SmartAccountFactoryAddress
+	if factoryAddress == "" {
+		if chain == nil {
+			return nil, fmt.Errorf("no SmartAccountFactoryAddress configured and chain %s is not in the registry", chainID)
+		}
+		factoryAddress = chain.SimpleAccountFactory(version).Hex()
+	}
+
+	fac, err := factory.NewFactory(common.HexToAddress(factoryAddress), client)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceKey := new(big.Int)
+	if params.NonceKey != nil {
+		if params.NonceKey.Sign() < 0 || params.NonceKey.BitLen() > 192 {
+			return nil, fmt.Errorf("nonce key %s does not fit in 192 bits", params.NonceKey)
+		}
+		nonceKey.Set(params.NonceKey)
+	}
+
+	feeOracle := params.FeeOracle
+	if feeOracle == nil {
+		feeOracle = &NetworkFeeOracle{
+			Client:    client,
+			Bundler:   bc,
+			Speed:     params.FeeSpeed,
+			MaxFeeCap: params.MaxFeeCap,
+		}
+	}
+
 	contracts := &ContractAddressParams{
-		factory:    params.SmartAccountFactoryAddress,
-		entrypoint: params.EntryPointAddress,
+		factory:    factoryAddress,
+		entrypoint: entryPointAddress.Hex(),
+	}
+
+	salt := big.NewInt(params.AccountIndex)
+	account, err := fac.GetAddress(&bind.CallOpts{Context: ctx}, owner, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.AccountAddress != "" {
+		if !common.IsHexAddress(params.AccountAddress) {
+			return nil, fmt.Errorf("invalid account address %q", params.AccountAddress)
+		}
+		account = common.HexToAddress(params.AccountAddress)
 	}
 
 	return &SmartAccountProvider{
-		Client:     client,
-		Owner:      owner,
-		SAFactory:  fac,
-		EntryPoint: ep,
-		PrivateKey: params.OwnerPrivateKey,
-		Contracts:  contracts,
+		Client:            client,
+		Owner:             owner,
+		SAFactory:         fac,
+		EntryPoint:        ep,
+		EntryPointV07:     epV07,
+		EntryPointVersion: version,
+		Bundler:           bc,
+		Signer:            signer,
+		Contracts:         contracts,
+		ChainID:           chainID,
+		NonceKey:          nonceKey,
+		GasMultipliers:    params.GasMultipliers,
+		FeeOracle:         feeOracle,
+		Paymaster:         paymaster,
+		Account:           account,
+		Salt:              salt,
+		VerifyUserOpHash:  params.VerifyUserOpHash,
+		deployed:          make(map[common.Address]bool),
 	}, nil
 }
 
-func (sap *SmartAccountProvider) signMessage(dataToSign []byte, privateKey *ecdsa.PrivateKey) (common.Hash, error) {
-	nonce, err := sap.Client.PendingNonceAt(context.Background(), sap.Owner)
+// userOpHash computes the userOpHash of op for the provider's EntryPoint version.
+func (sap *SmartAccountProvider) userOpHash(op *UserOperation) (common.Hash, error) {
+	entryPoint := common.HexToAddress(sap.Contracts.entrypoint)
+
+	if sap.EntryPointVersion != EntryPointV07 {
+		return GetUserOpHash(op, entryPoint, sap.ChainID)
+	}
+
+	v07, err := op.ToV07()
 	if err != nil {
 		return common.Hash{}, err
 	}
 
-	to := common.HexToAddress(sap.Contracts.entrypoint)
-	tx := types.NewTx(&types.AccessListTx{
-		Nonce:    nonce,
-		GasPrice: big.NewInt(20000000000),
-		Gas:      uint64(21000),
-		To:       &to,
-		Value:    big.NewInt(1000000000000000000),
-		Data:     dataToSign,
-	})
+	return GetUserOpHashV07(v07, entryPoint, sap.ChainID)
+}
+
+// rpcUserOp returns op in the bundler JSON-RPC format of the provider's EntryPoint version.
+func (sap *SmartAccountProvider) rpcUserOp(op *UserOperation) (any, error) {
+	return rpcUserOp(op, sap.EntryPointVersion)
+}
 
-	signature, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(80001)), privateKey)
+// rpcUserOp returns op in the JSON-RPC format of the given EntryPoint version.
+func rpcUserOp(op *UserOperation, version EntryPointVersion) (any, error) {
+	if version != EntryPointV07 {
+		return op, nil
+	}
 
+	return op.ToV07()
+}
+
+// verifyUserOpHash asks the EntryPoint for the userOpHash of op and makes sure it agrees
+// with the locally computed one, so a signature is never produced over the wrong digest.
+func (sap *SmartAccountProvider) verifyUserOpHash(ctx context.Context, op *UserOperation, hash common.Hash) error {
+	onChain, err := sap.onChainUserOpHash(ctx, op)
+	if err != nil {
+		return err
+	}
+
+	if onChain != hash {
+		return fmt.Errorf("userOpHash mismatch: local %s, entry point %s", hash, onChain)
+	}
+
+	return nil
+}
+
+// onChainUserOpHash calls getUserOpHash on the provider's EntryPoint.
+func (sap *SmartAccountProvider) onChainUserOpHash(ctx context.Context, op *UserOperation) (common.Hash, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	if sap.EntryPointVersion != EntryPointV07 {
+		hash, err := sap.EntryPoint.GetUserOpHash(opts, op.ToEntryPoint())
+		return hash, err
+	}
+
+	v07, err := op.ToV07()
 	if err != nil {
 		return common.Hash{}, err
 	}
 
-	return signature.Hash(), nil
+	packed, err := v07.Pack()
+	if err != nil {
+		return common.Hash{}, err
+	}
 
+	hash, err := sap.EntryPointV07.GetUserOpHash(opts, packed)
+	return hash, err
 }
 
 // createEthClient connects to an Ethereum node via the specified RPC endpoint
-// and returns an Ethereum client. It panics on connection errors.
-func createEthClient(rpc string) (*ethclient.Client, error) {
-	cl, err := ethclient.Dial(rpc)
+// and returns an Ethereum client. A nil httpClient uses the default transport.
+func createEthClient(ctx context.Context, rpcURL string, httpClient *http.Client) (*ethclient.Client, error) {
+	cl, err := dialRPC(ctx, rpcURL, httpClient)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return cl, nil
+	return ethclient.NewClient(cl), nil
 }
 
-// privateKeyToAddress converts a private key (in hexadecimal format) to an Ethereum address.
-func privateKeyToAddress(privateKey string) (common.Address, error) {
-	key, err := crypto.HexToECDSA(privateKey)
+// dialRPC connects a JSON-RPC client to url, using httpClient for HTTP endpoints when set.
+func dialRPC(ctx context.Context, url string, httpClient *http.Client) (*rpc.Client, error) {
+	var options []rpc.ClientOption
+	if httpClient != nil {
+		options = append(options, rpc.WithHTTPClient(httpClient))
+	}
+
+	return rpc.DialOptions(ctx, url, options...)
+}
+
+// createBundlerClient returns a client for the configured bundler along with the entry
+// points it supports. Without a BundlerURL the node at RPC is used, provided it answers
+// eth_supportedEntryPoints.
+func createBundlerClient(ctx context.Context, params SmartAccountProviderParams) (*bundler.Client, []common.Address, error) {
+	headers := make(http.Header)
+	for key, value := range params.BundlerHeaders {
+		headers.Set(key, value)
+	}
 
+	url := params.BundlerURL
+	if url == "" {
+		url = params.RPC
+	}
+
+	bc := bundler.NewClient(url, params.HTTPClient, headers)
+
+	supported, err := bc.SupportedEntryPoints(ctx)
 	if err != nil {
-		return common.Address{}, err
+		if params.BundlerURL == "" {
+			return nil, nil, fmt.Errorf("no BundlerURL configured and the RPC endpoint does not expose the bundler namespace: %w", err)
+		}
+		return nil, nil, fmt.Errorf("eth_supportedEntryPoints: %w", err)
 	}
 
-	return crypto.PubkeyToAddress(key.PublicKey), nil
+	return bc, supported, nil
 }
 
 // GetSmartAccountAddress retrieves the address of a smart account based on a given salt value.
 func (sap *SmartAccountProvider) GetSmartAccountAddress(salt int64) (common.Address, error) {
+	return sap.GetSmartAccountAddressContext(context.Background(), salt)
+}
 
-	address, err := sap.SAFactory.GetAddress(nil, sap.Owner, big.NewInt(salt))
+// GetSmartAccountAddressContext is GetSmartAccountAddress with a context for the factory call.
+func (sap *SmartAccountProvider) GetSmartAccountAddressContext(ctx context.Context, salt int64) (common.Address, error) {
+	address, err := sap.SAFactory.GetAddress(&bind.CallOpts{Context: ctx}, sap.Owner, big.NewInt(salt))
 	if err != nil {
 		return common.Address{}, err
 	}
@@ -117,86 +293,265 @@ func (sap *SmartAccountProvider) GetSmartAccountAddress(salt int64) (common.Addr
 	return address, nil
 }
 
-func buildUserOp(sender common.Address, nonce string, calldata []byte) UOps {
-	return UOps{
+// GetSmartAccountAddresses returns the addresses of the owner's smart accounts for the
+// indexes 0 through count-1, in order.
+func (sap *SmartAccountProvider) GetSmartAccountAddresses(count int64) ([]common.Address, error) {
+	return sap.GetSmartAccountAddressesContext(context.Background(), count)
+}
+
+// GetSmartAccountAddressesContext is GetSmartAccountAddresses with a context for the factory calls.
+func (sap *SmartAccountProvider) GetSmartAccountAddressesContext(ctx context.Context, count int64) ([]common.Address, error) {
+	addresses := make([]common.Address, 0, count)
+
+	for i := int64(0); i < count; i++ {
+		address, err := sap.GetSmartAccountAddressContext(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+// isDeployed reports whether the smart account at sender already has code. Only positive
+// results are cached, so a counterfactual account is re-checked until it is deployed.
+func (sap *SmartAccountProvider) isDeployed(ctx context.Context, sender common.Address) (bool, error) {
+	sap.deployedMu.Lock()
+	deployed := sap.deployed[sender]
+	sap.deployedMu.Unlock()
+
+	if deployed {
+		return true, nil
+	}
+
+	code, err := sap.Client.CodeAt(ctx, sender, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if len(code) == 0 {
+		return false, nil
+	}
+
+	sap.deployedMu.Lock()
+	sap.deployed[sender] = true
+	sap.deployedMu.Unlock()
+
+	return true, nil
+}
+
+// getInitCode returns the initCode for sender: empty once the account is deployed, otherwise
+// the factory address followed by the ABI-encoded createAccount(owner, salt) call.
+func (sap *SmartAccountProvider) getInitCode(ctx context.Context, sender common.Address, salt *big.Int) ([]byte, error) {
+	deployed, err := sap.isDeployed(ctx, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	if deployed {
+		return []byte{}, nil
+	}
+
+	derived, err := sap.SAFactory.GetAddress(&bind.CallOpts{Context: ctx}, sap.Owner, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if derived != sender {
+		return nil, fmt.Errorf("account %s is not deployed and is not the factory address for salt %s (%s)", sender, salt, derived)
+	}
+
+	factoryABI, err := factory.FactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	createAccount, err := factoryABI.Pack("createAccount", sap.Owner, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(common.HexToAddress(sap.Contracts.factory).Bytes(), createAccount...), nil
+}
+
+// GetNonce returns the EntryPoint nonce of sender for the given 192-bit key. The key occupies
+// the upper 192 bits of the returned value, so userops using different keys never collide.
+func (sap *SmartAccountProvider) GetNonce(sender common.Address, key *big.Int) (*big.Int, error) {
+	return sap.GetNonceContext(context.Background(), sender, key)
+}
+
+// GetNonceContext is GetNonce with a context for the EntryPoint call.
+func (sap *SmartAccountProvider) GetNonceContext(ctx context.Context, sender common.Address, key *big.Int) (*big.Int, error) {
+	if key == nil {
+		key = new(big.Int)
+	}
+
+	return sap.EntryPoint.GetNonce(&bind.CallOpts{Context: ctx}, sender, key)
+}
+
+// buildUserOp assembles an unsigned userop. Gas and fee fields are left at zero for
+// fillFees and fillGas.
+func buildUserOp(sender common.Address, nonce *big.Int, initCode []byte, calldata []byte) *UserOperation {
+	return &UserOperation{
 		Sender:               sender,
-		Nonce:                "0x" + nonce,
-		InitCode:             "0x",
-		CallData:             "0x" + hex.EncodeToString(calldata),
-		CallGasLimit:         "0x2710",
-		VerificationGasLimit: "0x2710",
-		PreVerificationGas:   "0x402db0",
-		MaxFeePerGas:         "0x17190c894e",
-		MaxPriorityFeePerGas: "0x3812ed1a0",
-		PaymasterAndData:     "0x",
+		Nonce:                nonce,
+		InitCode:             initCode,
+		CallData:             calldata,
+		CallGasLimit:         new(big.Int),
+		VerificationGasLimit: new(big.Int),
+		PreVerificationGas:   new(big.Int),
+		MaxFeePerGas:         new(big.Int),
+		MaxPriorityFeePerGas: new(big.Int),
+		PaymasterAndData:     []byte{},
+		Signature:            []byte{},
+	}
+}
+
+// SendUserOpsTransaction sends a userop that makes a single call from the smart account.
+// Gas fields set in overrides skip estimation, later overrides take precedence.
+func (sap *SmartAccountProvider) SendUserOpsTransaction(target TargetParams, overrides ...GasOverrides) (common.Hash, error) {
+	return sap.SendUserOpsTransactionContext(context.Background(), target, overrides...)
+}
+
+// SendUserOpsTransactionContext is SendUserOpsTransaction with a context that bounds every
+// node, bundler and paymaster request made while building and sending the userop.
+func (sap *SmartAccountProvider) SendUserOpsTransactionContext(ctx context.Context, target TargetParams, overrides ...GasOverrides) (common.Hash, error) {
+	return sap.sendUserOp(ctx, []TargetParams{target}, overrides)
+}
+
+// SendBatchUserOps sends a single userop that makes all the given calls, in order, from
+// the smart account. With EntryPoint v0.6 the batch cannot send value and fails with
+// ErrBatchValueUnsupported when a target does. Gas is estimated once for the whole batch.
+func (sap *SmartAccountProvider) SendBatchUserOps(targets []TargetParams, overrides ...GasOverrides) (common.Hash, error) {
+	return sap.SendBatchUserOpsContext(context.Background(), targets, overrides...)
+}
+
+// SendBatchUserOpsContext is SendBatchUserOps with a context that bounds every request made
+// while building and sending the userop.
+func (sap *SmartAccountProvider) SendBatchUserOpsContext(ctx context.Context, targets []TargetParams, overrides ...GasOverrides) (common.Hash, error) {
+	if len(targets) == 0 {
+		return common.Hash{}, errors.New("batch must contain at least one target")
 	}
+
+	return sap.sendUserOp(ctx, targets, overrides)
 }
 
-func (sap *SmartAccountProvider) SendUserOpsTransaction(target TargetParams) (any, error) {
-	// Nonce
-	nonce, err := sap.Client.PendingNonceAt(context.Background(), sap.Owner)
+// sendUserOp builds and submits a userop making the given calls.
+func (sap *SmartAccountProvider) sendUserOp(ctx context.Context, targets []TargetParams, overrides []GasOverrides) (common.Hash, error) {
+	uo, err := sap.prepareUserOp(ctx, targets, overrides)
 	if err != nil {
-		return 0, err
+		return common.Hash{}, err
 	}
 
-	sender, err := sap.GetSmartAccountAddress(int64(nonce))
+	return sap.SendUserOperation(ctx, uo)
+}
+
+// BuildUserOp returns the signed userop SendBatchUserOps would submit for targets, without
+// submitting it, so it can be inspected or simulated first and sent with SendUserOperation.
+func (sap *SmartAccountProvider) BuildUserOp(ctx context.Context, targets []TargetParams, overrides ...GasOverrides) (*UserOperation, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("userop must make at least one call")
+	}
+
+	return sap.prepareUserOp(ctx, targets, overrides)
+}
+
+// SendUserOperation submits a signed userop to the bundler and returns its userOpHash.
+func (sap *SmartAccountProvider) SendUserOperation(ctx context.Context, uo *UserOperation) (common.Hash, error) {
+	payload, err := sap.rpcUserOp(uo)
 	if err != nil {
-		return 0, err
+		return common.Hash{}, err
 	}
 
-	calldata, err := json.Marshal(target)
+	userOpHash, err := sap.Bundler.SendUserOperation(ctx, payload, common.HexToAddress(sap.Contracts.entrypoint))
 	if err != nil {
-		return 0, err
+		return common.Hash{}, decodeEntryPointError(err)
 	}
 
-	nonceInHex := strconv.FormatInt(int64(nonce), 16)
+	return userOpHash, nil
+}
 
-	uo := buildUserOp(sender, nonceInHex, calldata)
+// prepareUserOp builds, prices, sponsors and signs a userop making the given calls. With a
+// token paymaster an approve call is estimated ahead of the calls and kept only when the
+// paymaster's allowance does not cover the quoted cost.
+func (sap *SmartAccountProvider) prepareUserOp(ctx context.Context, targets []TargetParams, overrides []GasOverrides) (*UserOperation, error) {
+	sender := sap.Account
 
-	privateKey, err := crypto.HexToECDSA(sap.PrivateKey)
+	initCode, err := sap.getInitCode(ctx, sender, sap.Salt)
 	if err != nil {
-		fmt.Println("Failed to sign the UOps struct:", err)
-		return 0, err
+		return nil, err
 	}
 
-	signature, err := sap.signMessage(calldata, privateKey)
+	nonce, err := sap.GetNonceContext(ctx, sender, sap.NonceKey)
+	if err != nil {
+		return nil, err
+	}
 
+	approval, err := sap.quoteTokenApproval(ctx, sender)
 	if err != nil {
-		fmt.Println("Failed to sign the UOps struct:", err)
-		return 0, err
+		return nil, err
 	}
 
-	uo.Signature = &signature
+	calls := targets
+	if approval != nil {
+		if calls, err = approval.withApproval(targets, maxApproval); err != nil {
+			return nil, err
+		}
+	}
 
-	var uoArray []any
-	uoArray = append(uoArray, uo)
-	uoArray = append(uoArray, sap.Contracts.entrypoint)
+	calldata, err := sap.encodeCalls(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	uo := buildUserOp(sender, nonce, initCode, calldata)
+
+	var gas GasOverrides
+	for _, o := range overrides {
+		gas = gas.merge(o)
+	}
 
-	bodyPayload, err := json.Marshal(UserOperationTxnPayload{
-		Id:      1,
-		Jsonrpc: "2.0",
-		Method:  "eth_sendUserOperation",
-		Params:  uoArray,
-	})
+	if err := sap.fillFees(ctx, uo, gas); err != nil {
+		return nil, err
+	}
 
+	stub, err := sap.applyPaymasterStub(ctx, uo)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	url := "https://polygon-mumbai.g.alchemy.com/v2/u-FhnHbTFL8OASxmdclXSWKS-YcypJzH"
+	if err := sap.fillGas(ctx, uo, gas); err != nil {
+		return nil, err
+	}
 
-	payload := strings.NewReader(string(bodyPayload))
+	if approval != nil {
+		err = sap.applyTokenPaymasterData(ctx, uo, stub, approval, targets)
+	} else {
+		err = sap.applyPaymasterData(ctx, uo, stub)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	req, _ := http.NewRequest("POST", url, payload)
+	userOpHash, err := sap.userOpHash(uo)
+	if err != nil {
+		return nil, err
+	}
 
-	req.Header.Add("accept", "application/json")
-	req.Header.Add("content-type", "application/json")
+	if sap.VerifyUserOpHash {
+		if err := sap.verifyUserOpHash(ctx, uo, userOpHash); err != nil {
+			return nil, err
+		}
+	}
 
-	res, _ := http.DefaultClient.Do(req)
+	signature, err := signHash(ctx, sap.Signer, userOpHash)
 
-	body, _ := io.ReadAll(res.Body)
-	defer res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the user operation: %w", err)
+	}
 
-	return string(body), nil
+	uo.Signature = signature
 
+	return uo, nil
 }
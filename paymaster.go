@@ -0,0 +1,300 @@
+package goaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+	"net/http"
+)
+
+// PaymasterData is what a paymaster contributes to a userop. For EntryPoint v0.6 it is
+// PaymasterAndData. For v0.7 it is either the separate Paymaster fields, or PaymasterAndData
+// in the packed v0.7 layout of the paymaster, its two 16-byte gas limits and its data.
+type PaymasterData struct {
+	PaymasterAndData              []byte          // Paymaster address followed by its data
+	Paymaster                     *common.Address // v0.7 paymaster address, used instead of PaymasterAndData
+	PaymasterData                 []byte          // v0.7 paymaster specific data
+	PaymasterVerificationGasLimit *big.Int        // Optional v0.7 gas limit of validatePaymasterUserOp, defaults to the estimate
+	PaymasterPostOpGasLimit       *big.Int        // Optional v0.7 gas limit of postOp, defaults to the estimate
+	CallGasLimit                  *big.Int        // Optional callGasLimit the paymaster signed over, replacing the estimate
+	VerificationGasLimit          *big.Int        // Optional verificationGasLimit the paymaster signed over
+	PreVerificationGas            *big.Int        // Optional preVerificationGas the paymaster signed over
+	IsFinal                       bool            // Stub data that can be sent as-is, skipping GetPaymasterData
+}
+
+// Paymaster sponsors userops. The provider asks for stub data before gas estimation and
+// for the final data once gas and fees are settled, then signs the op. Since the op is not
+// signed yet, it carries a well-formed dummy signature so paymaster services can simulate
+// it. With EntryPoint v0.7 op.PaymasterAndData uses the packed v0.7 layout, which ToV07
+// splits into the separate fields. Implementations must not modify op.
+type Paymaster interface {
+	// GetPaymasterStubData returns placeholder data that makes gas estimation representative.
+	// A nil result leaves paymasterAndData empty during estimation.
+	GetPaymasterStubData(ctx context.Context, op *UserOperation, entryPoint common.Address, chainID *big.Int) (*PaymasterData, error)
+	// GetPaymasterData returns the data the userop is sent with.
+	GetPaymasterData(ctx context.Context, op *UserOperation, entryPoint common.Address, chainID *big.Int) (*PaymasterData, error)
+}
+
+// entryPointBound is implemented by paymasters whose requests depend on the EntryPoint
+// release. The provider replaces them with the copy returned for its version.
+type entryPointBound interface {
+	forEntryPoint(version EntryPointVersion) (Paymaster, error)
+}
+
+// gasLimitSupplier is implemented by paymasters whose final data always carries the gas
+// limits they signed over. The provider skips the bundler estimate for them: its result
+// would be replaced anyway, and for an unfunded account a bundler checking the prefund
+// fails it with AA21 before the paymaster is ever asked.
+type gasLimitSupplier interface {
+	suppliesGasLimits()
+}
+
+// paymasterResponse is the result shape shared by the paymaster JSON-RPC methods. v0.6
+// services return paymasterAndData, v0.7 services the separate paymaster fields.
+type paymasterResponse struct {
+	PaymasterAndData              hexutil.Bytes   `json:"paymasterAndData"`
+	Paymaster                     *common.Address `json:"paymaster"`
+	PaymasterData                 hexutil.Bytes   `json:"paymasterData"`
+	PaymasterVerificationGasLimit *hexutil.Big    `json:"paymasterVerificationGasLimit"`
+	PaymasterPostOpGasLimit       *hexutil.Big    `json:"paymasterPostOpGasLimit"`
+	CallGasLimit                  *hexutil.Big    `json:"callGasLimit"`
+	VerificationGasLimit          *hexutil.Big    `json:"verificationGasLimit"`
+	PreVerificationGas            *hexutil.Big    `json:"preVerificationGas"`
+	IsFinal                       bool            `json:"isFinal"`
+}
+
+func (r *paymasterResponse) toPaymasterData() *PaymasterData {
+	return &PaymasterData{
+		PaymasterAndData:              r.PaymasterAndData,
+		Paymaster:                     r.Paymaster,
+		PaymasterData:                 r.PaymasterData,
+		PaymasterVerificationGasLimit: (*big.Int)(r.PaymasterVerificationGasLimit),
+		PaymasterPostOpGasLimit:       (*big.Int)(r.PaymasterPostOpGasLimit),
+		CallGasLimit:                  (*big.Int)(r.CallGasLimit),
+		VerificationGasLimit:          (*big.Int)(r.VerificationGasLimit),
+		PreVerificationGas:            (*big.Int)(r.PreVerificationGas),
+		IsFinal:                       r.IsFinal,
+	}
+}
+
+// callPaymaster performs a paymaster JSON-RPC call and checks that it returned data.
+func callPaymaster(ctx context.Context, client *rpc.Client, method string, params ...any) (*PaymasterData, error) {
+	var res *paymasterResponse
+	if err := client.CallContext(ctx, &res, method, params...); err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	if res == nil || (len(res.PaymasterAndData) == 0 && res.Paymaster == nil) {
+		return nil, fmt.Errorf("%s: paymaster returned neither paymasterAndData nor paymaster", method)
+	}
+
+	return res.toPaymasterData(), nil
+}
+
+// SponsorPaymaster uses the pm_sponsorUserOperation method offered by Pimlico, Stackup and
+// similar services. The sponsor estimates gas itself, so it supplies no stub data and the
+// provider does not ask the bundler for an estimate.
+type SponsorPaymaster struct {
+	client  *rpc.Client
+	policy  any               // Optional provider specific third parameter, e.g. a sponsorship policy
+	version EntryPointVersion // Userop format sent to the service
+}
+
+// NewSponsorPaymaster creates a pm_sponsorUserOperation paymaster. policy is sent as the
+// method's third parameter when non-nil. A nil httpClient uses the default transport.
+func NewSponsorPaymaster(url string, policy any, httpClient *http.Client) (*SponsorPaymaster, error) {
+	client, err := dialRPC(context.Background(), url, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SponsorPaymaster{client: client, policy: policy}, nil
+}
+
+// GetPaymasterStubData implements Paymaster.
+func (p *SponsorPaymaster) GetPaymasterStubData(context.Context, *UserOperation, common.Address, *big.Int) (*PaymasterData, error) {
+	return nil, nil
+}
+
+// GetPaymasterData implements Paymaster.
+func (p *SponsorPaymaster) GetPaymasterData(ctx context.Context, op *UserOperation, entryPoint common.Address, _ *big.Int) (*PaymasterData, error) {
+	payload, err := rpcUserOp(op, p.version)
+	if err != nil {
+		return nil, err
+	}
+
+	params := []any{payload, entryPoint}
+	if p.policy != nil {
+		params = append(params, p.policy)
+	}
+
+	return callPaymaster(ctx, p.client, "pm_sponsorUserOperation", params...)
+}
+
+func (p *SponsorPaymaster) suppliesGasLimits() {}
+
+func (p *SponsorPaymaster) forEntryPoint(version EntryPointVersion) (Paymaster, error) {
+	bound := *p
+	bound.version = version
+
+	return &bound, nil
+}
+
+// ERC7677Paymaster uses the pm_getPaymasterStubData and pm_getPaymasterData methods
+// standardised by ERC-7677.
+type ERC7677Paymaster struct {
+	client  *rpc.Client
+	context any               // Paymaster specific context passed to both methods
+	version EntryPointVersion // Userop format sent to the service
+}
+
+// NewERC7677Paymaster creates an ERC-7677 paymaster. paymasterContext is passed as the
+// context parameter of both methods. A nil httpClient uses the default transport.
+func NewERC7677Paymaster(url string, paymasterContext any, httpClient *http.Client) (*ERC7677Paymaster, error) {
+	client, err := dialRPC(context.Background(), url, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if paymasterContext == nil {
+		paymasterContext = map[string]any{}
+	}
+
+	return &ERC7677Paymaster{client: client, context: paymasterContext}, nil
+}
+
+// GetPaymasterStubData implements Paymaster.
+func (p *ERC7677Paymaster) GetPaymasterStubData(ctx context.Context, op *UserOperation, entryPoint common.Address, chainID *big.Int) (*PaymasterData, error) {
+	return p.call(ctx, "pm_getPaymasterStubData", op, entryPoint, chainID)
+}
+
+// GetPaymasterData implements Paymaster.
+func (p *ERC7677Paymaster) GetPaymasterData(ctx context.Context, op *UserOperation, entryPoint common.Address, chainID *big.Int) (*PaymasterData, error) {
+	return p.call(ctx, "pm_getPaymasterData", op, entryPoint, chainID)
+}
+
+// call sends op in the paymaster's userop format to one of the ERC-7677 methods.
+func (p *ERC7677Paymaster) call(ctx context.Context, method string, op *UserOperation, entryPoint common.Address, chainID *big.Int) (*PaymasterData, error) {
+	payload, err := rpcUserOp(op, p.version)
+	if err != nil {
+		return nil, err
+	}
+
+	return callPaymaster(ctx, p.client, method, payload, entryPoint, (*hexutil.Big)(chainID), p.context)
+}
+
+func (p *ERC7677Paymaster) forEntryPoint(version EntryPointVersion) (Paymaster, error) {
+	bound := *p
+	bound.version = version
+
+	return &bound, nil
+}
+
+// applyPaymasterStub sets the paymaster's stub data on uo ahead of gas estimation and
+// returns it, or nil when no paymaster is configured or it has no stub.
+func (sap *SmartAccountProvider) applyPaymasterStub(ctx context.Context, uo *UserOperation) (*PaymasterData, error) {
+	if sap.Paymaster == nil {
+		return nil, nil
+	}
+
+	stub, err := sap.Paymaster.GetPaymasterStubData(ctx, paymasterDraft(uo), common.HexToAddress(sap.Contracts.entrypoint), sap.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stub != nil {
+		if err := sap.setPaymasterData(uo, stub); err != nil {
+			return nil, err
+		}
+	}
+
+	return stub, nil
+}
+
+// applyPaymasterData sets the paymaster's final data on uo, including any gas limits the
+// paymaster signed over. Final stub data is used as-is.
+func (sap *SmartAccountProvider) applyPaymasterData(ctx context.Context, uo *UserOperation, stub *PaymasterData) error {
+	if sap.Paymaster == nil || (stub != nil && stub.IsFinal) {
+		return nil
+	}
+
+	data, err := sap.Paymaster.GetPaymasterData(ctx, paymasterDraft(uo), common.HexToAddress(sap.Contracts.entrypoint), sap.ChainID)
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		return errors.New("paymaster returned no data")
+	}
+
+	if err := sap.setPaymasterData(uo, data); err != nil {
+		return err
+	}
+	if data.CallGasLimit != nil {
+		uo.CallGasLimit = data.CallGasLimit
+	}
+	if data.VerificationGasLimit != nil {
+		uo.VerificationGasLimit = data.VerificationGasLimit
+	}
+	if data.PreVerificationGas != nil {
+		uo.PreVerificationGas = data.PreVerificationGas
+	}
+
+	if _, ok := sap.Paymaster.(gasLimitSupplier); ok {
+		if orZero(uo.CallGasLimit).Sign() == 0 || orZero(uo.VerificationGasLimit).Sign() == 0 || orZero(uo.PreVerificationGas).Sign() == 0 {
+			return errors.New("paymaster returned no gas limits and none were overridden")
+		}
+	}
+
+	return nil
+}
+
+// setPaymasterData stores the paymaster's contribution in uo.PaymasterAndData. For v0.7
+// the separate fields are packed, keeping uo's current paymaster gas limits where the
+// paymaster leaves them out, as ERC-7677 final data does.
+func (sap *SmartAccountProvider) setPaymasterData(uo *UserOperation, data *PaymasterData) error {
+	if sap.EntryPointVersion != EntryPointV07 {
+		if data.Paymaster != nil {
+			return errors.New("paymaster returned v0.7 paymaster fields for an EntryPoint v0.6 userop")
+		}
+		uo.PaymasterAndData = data.PaymasterAndData
+		return nil
+	}
+
+	if data.Paymaster == nil {
+		if len(data.PaymasterAndData) != 0 && len(data.PaymasterAndData) < paymasterFieldsLength {
+			return errors.New("v0.7 paymasterAndData must hold the paymaster address and both paymaster gas limits")
+		}
+		uo.PaymasterAndData = data.PaymasterAndData
+		return nil
+	}
+
+	verificationGasLimit, postOpGasLimit := paymasterGasLimits(uo.PaymasterAndData)
+	if data.PaymasterVerificationGasLimit != nil {
+		verificationGasLimit = data.PaymasterVerificationGasLimit
+	}
+	if data.PaymasterPostOpGasLimit != nil {
+		postOpGasLimit = data.PaymasterPostOpGasLimit
+	}
+
+	paymasterAndData, err := packPaymasterAndData(*data.Paymaster, verificationGasLimit, postOpGasLimit, data.PaymasterData)
+	if err != nil {
+		return err
+	}
+	uo.PaymasterAndData = paymasterAndData
+
+	return nil
+}
+
+// paymasterDraft returns a copy of uo carrying dummySignature. Paymaster services simulate
+// the op, and SimpleAccount reverts on an empty signature before reaching the paymaster.
+func paymasterDraft(uo *UserOperation) *UserOperation {
+	draft := uo.Copy()
+	draft.Signature = dummySignature
+
+	return draft
+}
@@ -0,0 +1,343 @@
+package goaa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pavankpdev/goaa/bundler"
+	entrypoint "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// recordingPaymaster is a Paymaster that records the ops it is asked about.
+type recordingPaymaster struct {
+	ops  []*UserOperation
+	data *PaymasterData
+}
+
+func (p *recordingPaymaster) GetPaymasterStubData(_ context.Context, op *UserOperation, _ common.Address, _ *big.Int) (*PaymasterData, error) {
+	p.ops = append(p.ops, op)
+	return p.data, nil
+}
+
+func (p *recordingPaymaster) GetPaymasterData(_ context.Context, op *UserOperation, _ common.Address, _ *big.Int) (*PaymasterData, error) {
+	p.ops = append(p.ops, op)
+	return p.data, nil
+}
+
+func TestPaymasterReceivesDummySignature(t *testing.T) {
+	paymaster := &recordingPaymaster{data: &PaymasterData{PaymasterAndData: []byte{0x01}}}
+	sap := &SmartAccountProvider{
+		Paymaster: paymaster,
+		Contracts: &ContractAddressParams{entrypoint: CanonicalEntryPointV06.Hex()},
+		ChainID:   big.NewInt(1),
+	}
+
+	uo := testUserOp()
+	uo.Signature = nil
+
+	if _, err := sap.applyPaymasterStub(context.Background(), uo); err != nil {
+		t.Fatal(err)
+	}
+	if err := sap.applyPaymasterData(context.Background(), uo, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paymaster.ops) != 2 {
+		t.Fatalf("paymaster called %d times, want 2", len(paymaster.ops))
+	}
+	for i, op := range paymaster.ops {
+		if !bytes.Equal(op.Signature, dummySignature) {
+			t.Errorf("call %d: signature = %x, want the dummy signature", i, op.Signature)
+		}
+		if op == uo {
+			t.Errorf("call %d: paymaster received the provider's own op", i)
+		}
+	}
+
+	if uo.Signature != nil {
+		t.Errorf("op signature = %x, want it left unset", uo.Signature)
+	}
+}
+
+func TestSetPaymasterData(t *testing.T) {
+	paymaster := common.HexToAddress("0x0000000000325602a77416A16136FDafd04b299f")
+	packed := func(verificationGasLimit, postOpGasLimit int64, data string) []byte {
+		pmd, err := packPaymasterAndData(paymaster, big.NewInt(verificationGasLimit), big.NewInt(postOpGasLimit), hexutil.MustDecode(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pmd
+	}
+
+	tests := []struct {
+		name    string
+		version EntryPointVersion
+		current []byte // uo.PaymasterAndData before the call
+		data    *PaymasterData
+		want    []byte
+		fails   bool
+	}{
+		{
+			name:    "v0.6 paymasterAndData",
+			version: EntryPointV06,
+			data:    &PaymasterData{PaymasterAndData: hexutil.MustDecode("0x01")},
+			want:    hexutil.MustDecode("0x01"),
+		},
+		{
+			name:    "v0.6 rejects v0.7 fields",
+			version: EntryPointV06,
+			data:    &PaymasterData{Paymaster: &paymaster},
+			fails:   true,
+		},
+		{
+			name:    "v0.7 separate fields",
+			version: EntryPointV07,
+			data: &PaymasterData{
+				Paymaster:                     &paymaster,
+				PaymasterData:                 hexutil.MustDecode("0xabcd"),
+				PaymasterVerificationGasLimit: big.NewInt(40000),
+				PaymasterPostOpGasLimit:       big.NewInt(20000),
+			},
+			want: packed(40000, 20000, "0xabcd"),
+		},
+		{
+			name:    "v0.7 final data keeps the current gas limits",
+			version: EntryPointV07,
+			current: packed(60000, 30000, "0x"),
+			data:    &PaymasterData{Paymaster: &paymaster, PaymasterData: hexutil.MustDecode("0xabcd")},
+			want:    packed(60000, 30000, "0xabcd"),
+		},
+		{
+			name:    "v0.7 packed paymasterAndData",
+			version: EntryPointV07,
+			data:    &PaymasterData{PaymasterAndData: packed(1, 2, "0x")},
+			want:    packed(1, 2, "0x"),
+		},
+		{
+			name:    "v0.7 rejects v0.6 paymasterAndData",
+			version: EntryPointV07,
+			data:    &PaymasterData{PaymasterAndData: paymaster.Bytes()},
+			fails:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sap := &SmartAccountProvider{EntryPointVersion: tt.version}
+			uo := &UserOperation{PaymasterAndData: tt.current}
+
+			err := sap.setPaymasterData(uo, tt.data)
+			if tt.fails {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(uo.PaymasterAndData, tt.want) {
+				t.Errorf("paymasterAndData = %x, want %x", uo.PaymasterAndData, tt.want)
+			}
+		})
+	}
+}
+
+func TestERC7677PaymasterV07(t *testing.T) {
+	paymaster := common.HexToAddress("0x0000000000325602a77416A16136FDafd04b299f")
+
+	var ops []map[string]json.RawMessage // Userops received, in order
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+			return
+		}
+
+		var op map[string]json.RawMessage
+		if err := json.Unmarshal(req.Params[0], &op); err != nil {
+			t.Errorf("decoding userop: %v", err)
+		}
+		ops = append(ops, op)
+
+		// ERC-7677 stub data carries the paymaster gas limits, final data does not.
+		result := map[string]any{"paymaster": paymaster, "paymasterData": "0xabcd"}
+		if req.Method == "pm_getPaymasterStubData" {
+			result["paymasterData"] = "0x"
+			result["paymasterVerificationGasLimit"] = "0x9c40"
+			result["paymasterPostOpGasLimit"] = "0x4e20"
+		}
+
+		w.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+
+	unbound, err := NewERC7677Paymaster(server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bound, err := unbound.forEntryPoint(EntryPointV07)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sap := &SmartAccountProvider{
+		Paymaster:         bound,
+		EntryPointVersion: EntryPointV07,
+		Contracts:         &ContractAddressParams{entrypoint: CanonicalEntryPointV07.Hex()},
+		ChainID:           big.NewInt(1),
+	}
+	uo := testUserOp()
+	uo.InitCode = nil
+	uo.PaymasterAndData = nil
+
+	stub, err := sap.applyPaymasterStub(context.Background(), uo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sap.applyPaymasterData(context.Background(), uo, stub); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := packPaymasterAndData(paymaster, big.NewInt(40000), big.NewInt(20000), hexutil.MustDecode("0xabcd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(uo.PaymasterAndData, want) {
+		t.Errorf("paymasterAndData = %x, want %x", uo.PaymasterAndData, want)
+	}
+
+	if len(ops) != 2 {
+		t.Fatalf("service called %d times, want 2", len(ops))
+	}
+	for i, op := range ops {
+		if _, ok := op["paymasterAndData"]; ok {
+			t.Errorf("call %d: userop sent in the v0.6 format", i)
+		}
+	}
+	if got := string(ops[1]["paymasterVerificationGasLimit"]); got != `"0x9c40"` {
+		t.Errorf("final request paymasterVerificationGasLimit = %s, want the stub's 0x9c40", got)
+	}
+}
+
+func TestVerifyingPaymasterRejectsV07(t *testing.T) {
+	if _, err := (&VerifyingPaymaster{}).forEntryPoint(EntryPointV07); err == nil {
+		t.Error("expected an error binding VerifyingPaymaster to EntryPoint v0.7")
+	}
+}
+
+func TestSponsoredSendSkipsGasEstimate(t *testing.T) {
+	sponsor := common.HexToAddress("0xe93eca6595fe94091dc1af46aac2a8b5d7990770")
+
+	// One stand-in answers for the node, the bundler and the sponsor. Its bundler fails
+	// estimation the way a prefund-checking one does for an unfunded account.
+	var methods []string
+	var sent map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+			return
+		}
+		methods = append(methods, req.Method)
+
+		res := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_getCode":
+			res["result"] = "0x60"
+		case "eth_call":
+			res["result"] = hexutil.Encode(make([]byte, 32))
+		case "eth_estimateUserOperationGas":
+			res["error"] = map[string]any{"code": -32500, "message": "AA21 didn't pay prefund"}
+		case "pm_sponsorUserOperation":
+			res["result"] = map[string]any{
+				"paymasterAndData":     hexutil.Encode(sponsor.Bytes()),
+				"callGasLimit":         "0x5208",
+				"verificationGasLimit": "0x186a0",
+				"preVerificationGas":   "0xc350",
+			}
+		case "eth_sendUserOperation":
+			if err := json.Unmarshal(req.Params[0], &sent); err != nil {
+				t.Errorf("decoding sent userop: %v", err)
+			}
+			res["result"] = common.Hash{0x01}
+		default:
+			res["error"] = map[string]any{"code": -32601, "message": "the method " + req.Method + " does not exist"}
+		}
+
+		w.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ethclient.Dial(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	ep, err := entrypoint.NewEntryPoint(CanonicalEntryPointV06, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewPrivateKeySigner("0x1934c4fa3a8c7130c55b4b2933657b584102c02e6fdc682394728822a714404e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	paymaster, err := NewSponsorPaymaster(server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sap := &SmartAccountProvider{
+		Client:     client,
+		EntryPoint: ep,
+		Bundler:    bundler.NewClient(server.URL, nil, nil),
+		Signer:     signer,
+		Contracts:  &ContractAddressParams{entrypoint: CanonicalEntryPointV06.Hex()},
+		ChainID:    big.NewInt(11155111),
+		FeeOracle:  FixedFeeOracle{MaxFeePerGas: big.NewInt(2e9), MaxPriorityFeePerGas: big.NewInt(1e9)},
+		Paymaster:  paymaster,
+		Account:    common.HexToAddress("0x94f3178AcB40d0E9c6967108e3711CF047D3240A"),
+		deployed:   map[common.Address]bool{},
+	}
+
+	if _, err := sap.SendUserOpsTransaction(TargetParams{Target: "0x1306b01bC3e4AD202612D3843387e94737673F53", Data: "0x"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, method := range methods {
+		if method == "eth_estimateUserOperationGas" {
+			t.Errorf("bundler asked for a gas estimate although the sponsor supplies the gas limits")
+		}
+	}
+
+	want := map[string]string{
+		"callGasLimit":         `"0x5208"`,
+		"verificationGasLimit": `"0x186a0"`,
+		"preVerificationGas":   `"0xc350"`,
+		"paymasterAndData":     `"` + hexutil.Encode(sponsor.Bytes()) + `"`,
+	}
+	for field, value := range want {
+		if got := string(sent[field]); got != value {
+			t.Errorf("sent %s = %s, want %s", field, got, value)
+		}
+	}
+}
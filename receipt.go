@@ -0,0 +1,55 @@
+package goaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pavankpdev/goaa/bundler"
+	"time"
+)
+
+const (
+	receiptPollInitial = 500 * time.Millisecond // First delay between receipt polls
+	receiptPollMax     = 10 * time.Second       // Upper bound of the exponential backoff
+)
+
+// WaitForUserOperationReceipt polls eth_getUserOperationReceipt until the userop is
+// included and returns its receipt. Polling backs off exponentially and stops when ctx is
+// cancelled or its deadline passes, so callers should bound ctx with a timeout.
+// Transport failures are retried, errors reported by the bundler are returned as-is.
+func (sap *SmartAccountProvider) WaitForUserOperationReceipt(ctx context.Context, userOpHash common.Hash) (*bundler.UserOperationReceipt, error) {
+	delay := receiptPollInitial
+	var lastErr error
+
+	for {
+		receipt, err := sap.Bundler.GetUserOperationReceipt(ctx, userOpHash)
+		if err == nil && receipt != nil {
+			return receipt, nil
+		}
+
+		var rpcErr *bundler.Error
+		if errors.As(err, &rpcErr) {
+			return nil, err
+		}
+		if err != nil {
+			lastErr = err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if lastErr != nil {
+				return nil, fmt.Errorf("waiting for userop %s: %w (last error: %v)", userOpHash, ctx.Err(), lastErr)
+			}
+			return nil, fmt.Errorf("waiting for userop %s: %w", userOpHash, ctx.Err())
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > receiptPollMax {
+			delay = receiptPollMax
+		}
+	}
+}
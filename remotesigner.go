@@ -0,0 +1,139 @@
+package goaa
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"net/http"
+)
+
+// remoteSignerMethods names the JSON-RPC methods a signing service exposes.
+type remoteSignerMethods struct {
+	signHash      func(address common.Address, hash common.Hash) (string, []any)
+	signTypedData func(address common.Address, data apitypes.TypedData) (string, []any)
+}
+
+// clefMethods targets Clef's external API.
+var clefMethods = remoteSignerMethods{
+	signHash: func(address common.Address, hash common.Hash) (string, []any) {
+		return "account_signData", []any{accounts.MimetypeTextPlain, address, hexutil.Bytes(hash.Bytes())}
+	},
+	signTypedData: func(address common.Address, data apitypes.TypedData) (string, []any) {
+		return "account_signTypedData", []any{address, data}
+	},
+}
+
+// web3SignerMethods targets the Ethereum JSON-RPC signing API served by Web3Signer's eth1 mode.
+var web3SignerMethods = remoteSignerMethods{
+	signHash: func(address common.Address, hash common.Hash) (string, []any) {
+		return "eth_sign", []any{address, hexutil.Bytes(hash.Bytes())}
+	},
+	signTypedData: func(address common.Address, data apitypes.TypedData) (string, []any) {
+		return "eth_signTypedData", []any{address, data}
+	},
+}
+
+// RemoteSigner is a Signer that delegates signing to an external service over JSON-RPC, so
+// the owner's key never enters this process. Every signature is checked to recover to the
+// configured address before it is returned.
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+	methods remoteSignerMethods
+}
+
+// NewClefSigner creates a signer backed by Clef's account_signData and
+// account_signTypedData. A nil httpClient uses the default transport.
+func NewClefSigner(url string, address common.Address, httpClient *http.Client) (*RemoteSigner, error) {
+	return newRemoteSigner(url, address, httpClient, clefMethods)
+}
+
+// NewWeb3Signer creates a signer backed by a Web3Signer-compatible eth_sign and
+// eth_signTypedData endpoint. A nil httpClient uses the default transport.
+func NewWeb3Signer(url string, address common.Address, httpClient *http.Client) (*RemoteSigner, error) {
+	return newRemoteSigner(url, address, httpClient, web3SignerMethods)
+}
+
+func newRemoteSigner(url string, address common.Address, httpClient *http.Client, methods remoteSignerMethods) (*RemoteSigner, error) {
+	client, err := dialRPC(context.Background(), url, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteSigner{
+		client:  client,
+		address: address,
+		methods: methods,
+	}, nil
+}
+
+// Address implements Signer.
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash implements Signer.
+func (s *RemoteSigner) SignHash(hash common.Hash) ([]byte, error) {
+	return s.SignHashContext(context.Background(), hash)
+}
+
+// SignHashContext implements ContextSigner.
+func (s *RemoteSigner) SignHashContext(ctx context.Context, hash common.Hash) ([]byte, error) {
+	method, params := s.methods.signHash(s.address, hash)
+
+	return s.sign(ctx, accounts.TextHash(hash.Bytes()), method, params)
+}
+
+// SignTypedData implements Signer.
+func (s *RemoteSigner) SignTypedData(data apitypes.TypedData) ([]byte, error) {
+	return s.SignTypedDataContext(context.Background(), data)
+}
+
+// SignTypedDataContext implements ContextSigner.
+func (s *RemoteSigner) SignTypedDataContext(ctx context.Context, data apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return nil, err
+	}
+
+	method, params := s.methods.signTypedData(s.address, data)
+
+	return s.sign(ctx, digest, method, params)
+}
+
+// sign calls the remote method and checks that the returned signature over digest
+// recovers to the signer's address.
+func (s *RemoteSigner) sign(ctx context.Context, digest []byte, method string, params []any) ([]byte, error) {
+	var signature hexutil.Bytes
+	if err := s.client.CallContext(ctx, &signature, method, params...); err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%s: expected a %d byte signature, got %d", method, crypto.SignatureLength, len(signature))
+	}
+
+	// Services differ in whether they return the recovery id as 0/1 or 27/28.
+	if signature[crypto.RecoveryIDOffset] < 27 {
+		signature[crypto.RecoveryIDOffset] += 27
+	}
+
+	recoverable := common.CopyBytes(signature)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+
+	pub, err := crypto.SigToPub(digest, recoverable)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	if signer := crypto.PubkeyToAddress(*pub); signer != s.address {
+		return nil, fmt.Errorf("%s: signature recovers to %s, expected %s", method, signer, s.address)
+	}
+
+	return signature, nil
+}
@@ -0,0 +1,250 @@
+package goaa
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubSigningService is an httptest stand-in for Clef or Web3Signer. It signs with key and
+// returns the recovery id as 0/1 unless ethereumV is set.
+type stubSigningService struct {
+	t         *testing.T
+	key       *ecdsa.PrivateKey
+	ethereumV bool
+	methods   []string // Methods called, in order
+}
+
+func (s *stubSigningService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.t.Errorf("decoding request: %v", err)
+		return
+	}
+	s.methods = append(s.methods, req.Method)
+
+	var digest []byte
+	switch req.Method {
+	case "account_signData", "eth_sign":
+		var data hexutil.Bytes
+		if err := json.Unmarshal(req.Params[len(req.Params)-1], &data); err != nil {
+			s.t.Errorf("decoding %s data: %v", req.Method, err)
+		}
+		if req.Method == "account_signData" {
+			var mimetype string
+			if err := json.Unmarshal(req.Params[0], &mimetype); err != nil || mimetype != accounts.MimetypeTextPlain {
+				s.t.Errorf("account_signData mimetype = %q, %v", mimetype, err)
+			}
+		}
+		digest = accounts.TextHash(data)
+	case "account_signTypedData", "eth_signTypedData":
+		var data apitypes.TypedData
+		if err := json.Unmarshal(req.Params[1], &data); err != nil {
+			s.t.Errorf("decoding %s data: %v", req.Method, err)
+		}
+		var err error
+		if digest, _, err = apitypes.TypedDataAndHash(data); err != nil {
+			s.t.Errorf("hashing typed data: %v", err)
+		}
+	default:
+		s.t.Errorf("unexpected method %s", req.Method)
+		return
+	}
+
+	signature, err := crypto.Sign(digest, s.key)
+	if err != nil {
+		s.t.Errorf("signing: %v", err)
+		return
+	}
+	if s.ethereumV {
+		signature[crypto.RecoveryIDOffset] += 27
+	}
+
+	w.Header().Set("content-type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      req.ID,
+		"result":  hexutil.Bytes(signature),
+	})
+}
+
+// newStubSigningService starts a signing service holding a fresh key.
+func newStubSigningService(t *testing.T) (*stubSigningService, string) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stub := &stubSigningService{t: t, key: key}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	return stub, server.URL
+}
+
+// testTypedData returns a small EIP-712 payload.
+func testTypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"Mail": {
+				{Name: "to", Type: "address"},
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "goaa",
+			ChainId: math.NewHexOrDecimal256(1),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":       "0x1306b01bC3e4AD202612D3843387e94737673F53",
+			"contents": "hello",
+		},
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	backends := []struct {
+		name    string
+		create  func(url string, address common.Address, httpClient *http.Client) (*RemoteSigner, error)
+		methods []string
+	}{
+		{name: "clef", create: NewClefSigner, methods: []string{"account_signData", "account_signTypedData"}},
+		{name: "web3signer", create: NewWeb3Signer, methods: []string{"eth_sign", "eth_signTypedData"}},
+	}
+
+	for _, backend := range backends {
+		for _, ethereumV := range []bool{false, true} {
+			name := backend.name + "/v 0 or 1"
+			if ethereumV {
+				name = backend.name + "/v 27 or 28"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				stub, url := newStubSigningService(t)
+				stub.ethereumV = ethereumV
+				address := crypto.PubkeyToAddress(stub.key.PublicKey)
+
+				signer, err := backend.create(url, address, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				hash := crypto.Keccak256Hash([]byte("userop"))
+				signature, err := signer.SignHash(hash)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertSignedBy(t, signature, accounts.TextHash(hash.Bytes()), address)
+
+				typedData := testTypedData()
+				signature, err = signer.SignTypedData(typedData)
+				if err != nil {
+					t.Fatal(err)
+				}
+				digest, _, err := apitypes.TypedDataAndHash(typedData)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertSignedBy(t, signature, digest, address)
+
+				if strings.Join(stub.methods, ",") != strings.Join(backend.methods, ",") {
+					t.Errorf("methods called = %v, want %v", stub.methods, backend.methods)
+				}
+			})
+		}
+	}
+}
+
+func TestRemoteSignerRejectsWrongSigner(t *testing.T) {
+	_, url := newStubSigningService(t)
+	expected := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+
+	signer, err := NewClefSigner(url, expected, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = signer.SignHash(crypto.Keccak256Hash([]byte("userop")))
+	if err == nil || !strings.Contains(err.Error(), "recovers to") {
+		t.Errorf("error = %v, want a recovery mismatch", err)
+	}
+}
+
+func TestRemoteSignerHonoursContext(t *testing.T) {
+	// A signing service that never answers, e.g. one waiting for manual approval in Clef.
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	var signer Signer
+	signer, err := NewClefSigner(server.URL, common.Address{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := signHash(ctx, signer, common.Hash{})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("error = %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("signing did not stop at the context deadline")
+	}
+}
+
+// assertSignedBy checks that signature is 65 bytes, uses a 27/28 recovery id and recovers
+// to want over digest.
+func assertSignedBy(t *testing.T, signature []byte, digest []byte, want common.Address) {
+	t.Helper()
+
+	if len(signature) != crypto.SignatureLength {
+		t.Fatalf("signature is %d bytes, want %d", len(signature), crypto.SignatureLength)
+	}
+	if v := signature[crypto.RecoveryIDOffset]; v != 27 && v != 28 {
+		t.Fatalf("v = %d, want 27 or 28", v)
+	}
+
+	recoverable := common.CopyBytes(signature)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(digest, recoverable)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := crypto.PubkeyToAddress(*pub); got != want {
+		t.Errorf("signature recovers to %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,196 @@
+package goaa
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/tyler-smith/go-bip39"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// Signer signs on behalf of the smart account's owner. Signatures are 65 bytes long with
+// the recovery id in the last byte as 27 or 28.
+type Signer interface {
+	// Address returns the owner address the signatures recover to.
+	Address() common.Address
+	// SignHash returns an EIP-191 personal_sign signature over hash, the scheme used by
+	// SimpleAccount to validate userOpHashes.
+	SignHash(hash common.Hash) ([]byte, error)
+	// SignTypedData returns an EIP-712 signature over data.
+	SignTypedData(data apitypes.TypedData) ([]byte, error)
+}
+
+// ContextSigner is a Signer whose requests can be bounded by a context, such as one calling
+// out to a remote signing service. The provider uses the context variants when available,
+// so a hanging signer cannot outlive the caller's deadline.
+type ContextSigner interface {
+	Signer
+	// SignHashContext is SignHash with a context bounding the signing request.
+	SignHashContext(ctx context.Context, hash common.Hash) ([]byte, error)
+	// SignTypedDataContext is SignTypedData with a context bounding the signing request.
+	SignTypedDataContext(ctx context.Context, data apitypes.TypedData) ([]byte, error)
+}
+
+// signHash signs hash with signer, passing ctx along when it is a ContextSigner.
+func signHash(ctx context.Context, signer Signer, hash common.Hash) ([]byte, error) {
+	if contextSigner, ok := signer.(ContextSigner); ok {
+		return contextSigner.SignHashContext(ctx, hash)
+	}
+
+	return signer.SignHash(hash)
+}
+
+// PrivateKeySigner is a Signer backed by an in-memory ECDSA key.
+type PrivateKeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewPrivateKeySigner creates a signer from a hex encoded private key, with or without a 0x prefix.
+func NewPrivateKeySigner(hexKey string) (*PrivateKeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrivateKeySignerFromECDSA(key), nil
+}
+
+// NewPrivateKeySignerFromECDSA creates a signer from a parsed ECDSA key.
+func NewPrivateKeySignerFromECDSA(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// NewKeystoreSigner decrypts a go-ethereum (Web3 Secret Storage) keystore file with
+// passphrase and returns a signer for the key it holds.
+func NewKeystoreSigner(path string, passphrase string) (*PrivateKeySigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrivateKeySignerFromECDSA(key.PrivateKey), nil
+}
+
+// NewMnemonicSigner derives a key from a BIP-39 mnemonic and optional passphrase along a
+// BIP-32 derivation path such as "m/44'/60'/0'/0/0". An empty path uses that default.
+func NewMnemonicSigner(mnemonic string, passphrase string, path string) (*PrivateKeySigner, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid BIP-39 mnemonic")
+	}
+
+	derivationPath := accounts.DefaultBaseDerivationPath
+	if path != "" {
+		parsed, err := accounts.ParseDerivationPath(path)
+		if err != nil {
+			return nil, err
+		}
+		derivationPath = parsed
+	}
+
+	seed := bip39.NewSeed(mnemonic, passphrase)
+
+	key, err := deriveKey(seed, derivationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrivateKeySignerFromECDSA(key), nil
+}
+
+// Address implements Signer.
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash implements Signer.
+func (s *PrivateKeySigner) SignHash(hash common.Hash) ([]byte, error) {
+	return s.sign(accounts.TextHash(hash.Bytes()))
+}
+
+// SignTypedData implements Signer.
+func (s *PrivateKeySigner) SignTypedData(data apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sign(digest)
+}
+
+// sign signs a 32-byte digest, shifting the recovery id to 27/28.
+func (s *PrivateKeySigner) sign(digest []byte) ([]byte, error) {
+	signature, err := crypto.Sign(digest, s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return signature, nil
+}
+
+// deriveKey walks a BIP-32 derivation path from seed and returns the resulting private key.
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	curveOrder := crypto.S256().Params().N
+	key, chainCode := new(big.Int).SetBytes(sum[:32]), sum[32:]
+	if key.Sign() == 0 || key.Cmp(curveOrder) >= 0 {
+		return nil, errors.New("seed produces an invalid master key")
+	}
+
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0}, math.PaddedBigBytes(key, 32)...)
+		} else {
+			priv, err := crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+			if err != nil {
+				return nil, err
+			}
+			data = crypto.CompressPubkey(&priv.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(curveOrder) >= 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+
+		key = tweak.Add(tweak, key)
+		key.Mod(key, curveOrder)
+		if key.Sign() == 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		chainCode = sum[32:]
+	}
+
+	return crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+}
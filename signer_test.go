@@ -0,0 +1,158 @@
+package goaa
+
+import (
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// TestDeriveKeyBIP32 checks deriveKey against BIP-32 test vector 1, which mixes hardened
+// and non-hardened steps.
+func TestDeriveKeyBIP32(t *testing.T) {
+	seed := hexutil.MustDecode("0x000102030405060708090a0b0c0d0e0f")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "m", want: "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{path: "m/0'", want: "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{path: "m/0'/1", want: "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{path: "m/0'/1/2'", want: "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{path: "m/0'/1/2'/2", want: "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{path: "m/0'/1/2'/2/1000000000", want: "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path := accounts.DerivationPath{}
+			if tt.path != "m" {
+				var err error
+				if path, err = accounts.ParseDerivationPath(tt.path); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			key, err := deriveKey(seed, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := common.Bytes2Hex(crypto.FromECDSA(key)); got != tt.want {
+				t.Errorf("key = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMnemonicSeedBIP39 checks the seed derivation against the BIP-39 reference vector,
+// which uses the passphrase "TREZOR".
+func TestMnemonicSeedBIP39(t *testing.T) {
+	want := "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+
+	if got := common.Bytes2Hex(bip39.NewSeed(testMnemonic, "TREZOR")); got != want {
+		t.Errorf("seed = %s, want %s", got, want)
+	}
+}
+
+func TestNewMnemonicSigner(t *testing.T) {
+	tests := []struct {
+		name       string
+		passphrase string
+		path       string
+		want       common.Address
+	}{
+		{name: "default path", want: common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")},
+		{name: "explicit path", path: "m/44'/60'/0'/0/0", want: common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")},
+		{name: "second account", path: "m/44'/60'/0'/0/1", want: common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewMnemonicSigner(testMnemonic, tt.passphrase, tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if signer.Address() != tt.want {
+				t.Errorf("address = %s, want %s", signer.Address(), tt.want)
+			}
+		})
+	}
+
+	if _, err := NewMnemonicSigner("abandon abandon abandon", "", ""); err == nil {
+		t.Error("expected an error for an invalid mnemonic")
+	}
+	if _, err := NewMnemonicSigner(testMnemonic, "", "m/44'/x"); err == nil {
+		t.Error("expected an error for an invalid path")
+	}
+}
+
+func TestNewKeystoreSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := keystore.EncryptKey(&keystore.Key{
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: key,
+	}, "correct horse", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, encrypted, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewKeystoreSigner(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := crypto.PubkeyToAddress(key.PublicKey); signer.Address() != want {
+		t.Errorf("address = %s, want %s", signer.Address(), want)
+	}
+
+	if _, err := NewKeystoreSigner(path, "wrong"); err == nil {
+		t.Error("expected an error for a wrong passphrase")
+	}
+}
+
+func TestPrivateKeySignerSignHash(t *testing.T) {
+	signer, err := NewPrivateKeySigner("0x1934c4fa3a8c7130c55b4b2933657b584102c02e6fdc682394728822a714404e")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := crypto.Keccak256Hash([]byte("userop"))
+	signature, err := signer.SignHash(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(signature) != 65 || (signature[64] != 27 && signature[64] != 28) {
+		t.Fatalf("signature %x is not 65 bytes with v of 27 or 28", signature)
+	}
+
+	recoverable := common.CopyBytes(signature)
+	recoverable[64] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash(hash.Bytes()), recoverable)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := crypto.PubkeyToAddress(*pub); got != signer.Address() {
+		t.Errorf("signature recovers to %s, want %s", got, signer.Address())
+	}
+}
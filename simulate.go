@@ -0,0 +1,228 @@
+package goaa
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	entrypoint "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"time"
+)
+
+// Errors reported by SimulateValidation alongside a ValidationResult.
+var (
+	ErrSignatureFailed = errors.New("account or paymaster rejected the signature")
+	ErrNotYetValid     = errors.New("userop is not valid yet")
+	ErrExpired         = errors.New("userop has expired")
+)
+
+// ErrSimulationUnsupported is returned by the simulation methods for EntryPoint v0.7, which
+// moved simulateValidation and simulateHandleOp out of the EntryPoint.
+var ErrSimulationUnsupported = errors.New("EntryPoint v0.7 does not offer on-chain simulation")
+
+// SimulateValidation runs the EntryPoint's simulateValidation for op through eth_call and
+// returns the decoded ValidationResult. Validation failures are returned as the decoded
+// EntryPoint error, e.g. a *FailedOpError. When validation passes but the signature check
+// failed or the validity window excludes the current time, both the result and one of
+// ErrSignatureFailed, ErrNotYetValid or ErrExpired are returned.
+func (sap *SmartAccountProvider) SimulateValidation(ctx context.Context, op *UserOperation) (*ValidationResult, error) {
+	if sap.EntryPointVersion == EntryPointV07 {
+		return nil, ErrSimulationUnsupported
+	}
+
+	entryPointABI, err := entrypoint.EntryPointMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := entryPointABI.Pack("simulateValidation", op.ToEntryPoint())
+	if err != nil {
+		return nil, err
+	}
+
+	revert, err := sap.callEntryPoint(ctx, "simulateValidation", data)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := revert.(*ValidationResult)
+	if !ok {
+		return nil, revert
+	}
+
+	info := result.ReturnInfo
+	now := big.NewInt(time.Now().Unix())
+
+	switch {
+	case info.SigFailed:
+		return result, ErrSignatureFailed
+	case info.ValidAfter != nil && info.ValidAfter.Cmp(now) > 0:
+		return result, fmt.Errorf("%w: valid after %s", ErrNotYetValid, info.ValidAfter)
+	case info.ValidUntil != nil && info.ValidUntil.Sign() > 0 && info.ValidUntil.Cmp(now) < 0:
+		return result, fmt.Errorf("%w: valid until %s", ErrExpired, info.ValidUntil)
+	}
+
+	return result, nil
+}
+
+// callEntryPoint eth_calls one of the EntryPoint's always-reverting simulation methods and
+// returns its revert decoded by DecodeEntryPointError. Calls that do not revert or whose
+// revert data is not an EntryPoint error fail with a plain error.
+func (sap *SmartAccountProvider) callEntryPoint(ctx context.Context, method string, data []byte) (revert error, err error) {
+	entryPoint := common.HexToAddress(sap.Contracts.entrypoint)
+
+	_, callErr := sap.Client.CallContract(ctx, ethereum.CallMsg{To: &entryPoint, Data: data}, nil)
+	if callErr == nil {
+		return nil, fmt.Errorf("%s did not revert", method)
+	}
+
+	revertBytes, ok := revertData(callErr)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", method, callErr)
+	}
+
+	revert = DecodeEntryPointError(revertBytes)
+	if revert == nil {
+		return nil, fmt.Errorf("%s: unrecognised revert data %x: %w", method, revertBytes, callErr)
+	}
+
+	return revert, nil
+}
+
+// SimulateUserOp dry-runs op with the EntryPoint's simulateHandleOp through eth_call and
+// returns the decoded ExecutionResult. Paid is what the op would be charged. After the op,
+// the EntryPoint calls target with targetCallData and reports that call's outcome in
+// TargetSuccess and TargetResult; a zero target skips it. That call runs on the state left
+// by the op, so it cannot stand in for the op's own call.
+//
+// simulateHandleOp does not report whether the op's own call succeeded. SimulateUserOp
+// recovers it by tracing the simulation with debug_traceCall and fills in CallSuccess and
+// CallResult; when the node does not support tracing, CallTraced is false and the op's own
+// outcome is unknown. Validation failures are returned as the decoded EntryPoint error.
+func (sap *SmartAccountProvider) SimulateUserOp(ctx context.Context, op *UserOperation, target common.Address, targetCallData []byte) (*ExecutionResult, error) {
+	if sap.EntryPointVersion == EntryPointV07 {
+		return nil, ErrSimulationUnsupported
+	}
+
+	entryPointABI, err := entrypoint.EntryPointMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	if targetCallData == nil {
+		targetCallData = []byte{}
+	}
+
+	data, err := entryPointABI.Pack("simulateHandleOp", op.ToEntryPoint(), target, targetCallData)
+	if err != nil {
+		return nil, err
+	}
+
+	revert, err := sap.callEntryPoint(ctx, "simulateHandleOp", data)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := revert.(*ExecutionResult)
+	if !ok {
+		return nil, revert
+	}
+
+	if frame, err := sap.traceAccountCall(ctx, op, data); err == nil {
+		result.CallTraced = true
+		result.CallSuccess = frame == nil || frame.Error == ""
+		if frame != nil {
+			result.CallResult = frame.Output
+		}
+	}
+
+	return result, nil
+}
+
+// callFrame is a call in the output of debug_traceCall's callTracer.
+type callFrame struct {
+	From   common.Address  `json:"from"`
+	To     *common.Address `json:"to"`
+	Input  hexutil.Bytes   `json:"input"`
+	Output hexutil.Bytes   `json:"output"`
+	Error  string          `json:"error"`
+	Calls  []callFrame     `json:"calls"`
+}
+
+// traceAccountCall traces the EntryPoint call data with callTracer and returns the frame in
+// which the EntryPoint calls op.Sender with op.CallData. It returns nil and no error for ops
+// without call data, which the EntryPoint does not call.
+func (sap *SmartAccountProvider) traceAccountCall(ctx context.Context, op *UserOperation, data []byte) (*callFrame, error) {
+	if len(op.CallData) == 0 {
+		return nil, nil
+	}
+
+	entryPoint := common.HexToAddress(sap.Contracts.entrypoint)
+	call := map[string]any{"to": entryPoint, "data": hexutil.Bytes(data)}
+
+	var trace callFrame
+	if err := sap.Client.Client().CallContext(ctx, &trace, "debug_traceCall", call, "latest", map[string]any{"tracer": "callTracer"}); err != nil {
+		return nil, err
+	}
+
+	// The op's call precedes the optional target call, so the first match is the op's.
+	if frame := findCall(&trace, entryPoint, op.Sender, op.CallData); frame != nil {
+		return frame, nil
+	}
+
+	return nil, errors.New("trace does not contain the account call")
+}
+
+// findCall returns the first frame, in call order, from from to to with input.
+func findCall(frame *callFrame, from common.Address, to common.Address, input []byte) *callFrame {
+	if frame.From == from && frame.To != nil && *frame.To == to && bytes.Equal(frame.Input, input) {
+		return frame
+	}
+
+	for i := range frame.Calls {
+		if found := findCall(&frame.Calls[i], from, to, input); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// CallRevertReason returns why the op's own call reverted in a simulation, in the form of
+// TargetRevertReason. It is empty when the call succeeded or was not traced.
+func (r *ExecutionResult) CallRevertReason() string {
+	if !r.CallTraced {
+		return ""
+	}
+
+	return revertReason(r.CallSuccess, r.CallResult)
+}
+
+// TargetRevertReason returns why the target call of a simulation reverted: the decoded
+// Error(string) or Panic(uint256) reason when there is one, the raw revert data otherwise.
+// It is empty when the call succeeded.
+func (r *ExecutionResult) TargetRevertReason() string {
+	return revertReason(r.TargetSuccess, r.TargetResult)
+}
+
+// revertReason describes the revert data of a failed call, or returns "" for a success.
+func revertReason(success bool, data []byte) string {
+	if success {
+		return ""
+	}
+
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+
+	if len(data) == 0 {
+		return "reverted without data"
+	}
+
+	return hexutil.Encode(data)
+}
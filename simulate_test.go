@@ -0,0 +1,143 @@
+package goaa
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	entrypoint "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubNode is an httptest stand-in node answering eth_call with a fixed revert and
+// debug_traceCall with a fixed trace, or method not found when trace is nil.
+func stubNode(t *testing.T, revert []byte, trace any) *ethclient.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+			return
+		}
+
+		res := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		switch {
+		case req.Method == "eth_call":
+			res["error"] = map[string]any{"code": 3, "message": "execution reverted", "data": hexutil.Encode(revert)}
+		case req.Method == "debug_traceCall" && trace != nil:
+			res["result"] = trace
+		default:
+			res["error"] = map[string]any{"code": -32601, "message": "the method " + req.Method + " does not exist"}
+		}
+
+		w.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ethclient.Dial(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+// executionResultRevert ABI-encodes an ExecutionResult revert.
+func executionResultRevert(t *testing.T, paid int64, targetSuccess bool, targetResult []byte) []byte {
+	t.Helper()
+
+	entryPointABI, err := entrypoint.EntryPointMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	abiErr := entryPointABI.Errors["ExecutionResult"]
+	args, err := abiErr.Inputs.Pack(big.NewInt(50000), big.NewInt(paid), big.NewInt(0), big.NewInt(0), targetSuccess, targetResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return append(abiErr.ID[:4], args...)
+}
+
+// revertWithReason ABI-encodes an Error(string) revert.
+func revertWithReason(reason string) []byte {
+	stringType, _ := abi.NewType("string", "", nil)
+	data, _ := abi.Arguments{{Type: stringType}}.Pack(reason)
+
+	return append(hexutil.MustDecode("0x08c379a0"), data...)
+}
+
+func TestSimulateUserOp(t *testing.T) {
+	op := testUserOp()
+	entryPoint := CanonicalEntryPointV06
+	reason := revertWithReason("insufficient balance")
+
+	// accountCall is the frame of the EntryPoint calling the account, nested the way
+	// simulateHandleOp reaches it through innerHandleOp.
+	trace := func(accountCall map[string]any) map[string]any {
+		return map[string]any{
+			"from": common.Address{}, "to": entryPoint, "input": "0x", "error": "execution reverted",
+			"calls": []any{
+				map[string]any{"from": entryPoint, "to": op.Sender, "input": "0x3a871cdd"},
+				map[string]any{
+					"from": entryPoint, "to": entryPoint, "input": "0x1d732756",
+					"calls": []any{accountCall},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		trace      any
+		traced     bool
+		success    bool
+		callReason string
+	}{
+		{
+			name:   "call succeeds",
+			trace:  trace(map[string]any{"from": entryPoint, "to": op.Sender, "input": hexutil.Encode(op.CallData), "output": "0x"}),
+			traced: true, success: true,
+		},
+		{
+			name:   "call reverts",
+			trace:  trace(map[string]any{"from": entryPoint, "to": op.Sender, "input": hexutil.Encode(op.CallData), "output": hexutil.Encode(reason), "error": "execution reverted"}),
+			traced: true, callReason: "insufficient balance",
+		},
+		{name: "tracing unsupported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sap := &SmartAccountProvider{
+				Client:    stubNode(t, executionResultRevert(t, 12345, false, nil), tt.trace),
+				Contracts: &ContractAddressParams{entrypoint: entryPoint.Hex()},
+			}
+
+			result, err := sap.SimulateUserOp(context.Background(), op, common.Address{}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Paid.Int64() != 12345 || result.PreOpGas.Int64() != 50000 {
+				t.Errorf("paid = %s, preOpGas = %s", result.Paid, result.PreOpGas)
+			}
+			if result.CallTraced != tt.traced || result.CallSuccess != tt.success {
+				t.Errorf("traced = %t, success = %t, want %t, %t", result.CallTraced, result.CallSuccess, tt.traced, tt.success)
+			}
+			if got := result.CallRevertReason(); got != tt.callReason {
+				t.Errorf("CallRevertReason() = %q, want %q", got, tt.callReason)
+			}
+		})
+	}
+}
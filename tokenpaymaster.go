@@ -0,0 +1,325 @@
+package goaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pavankpdev/goaa/bundler"
+	erc20 "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"net/http"
+)
+
+var (
+	// exchangeRateScale is the wei amount TokenQuote.ExchangeRate is quoted against.
+	exchangeRateScale = big.NewInt(1e18)
+
+	// maxApproval is the placeholder approve amount used while estimating gas. It encodes to
+	// the same length as any real amount, with no zero bytes, so estimates are an upper bound.
+	maxApproval = math.MaxBig256
+)
+
+// TokenQuote prices gas in an ERC-20 token for a token paymaster.
+type TokenQuote struct {
+	Paymaster    common.Address // Paymaster that pulls the token and must be approved
+	Token        common.Address // ERC-20 token the userop pays in
+	ExchangeRate *big.Int       // Token units charged per 1e18 wei of gas
+	PostOpGas    *big.Int       // Gas the paymaster spends in postOp, charged on top of the userop
+}
+
+// Cost returns the most the paymaster can charge for the EntryPoint v0.6 op, in token units,
+// based on the op's gas limits and maxFeePerGas. The actual charge is usually lower.
+func (q *TokenQuote) Cost(op *UserOperation) *big.Int {
+	// With a paymaster the EntryPoint reserves verificationGasLimit for validatePaymasterUserOp
+	// and postOp as well as for the account.
+	gas := new(big.Int).Mul(orZero(op.VerificationGasLimit), big.NewInt(3))
+	gas.Add(gas, orZero(op.CallGasLimit))
+	gas.Add(gas, orZero(op.PreVerificationGas))
+	gas.Add(gas, orZero(q.PostOpGas))
+
+	return q.price(gas, op.MaxFeePerGas)
+}
+
+// CostV07 is Cost for an EntryPoint v0.7 op, whose paymaster phases have their own gas
+// limits.
+func (q *TokenQuote) CostV07(op *UserOperationV07) *big.Int {
+	gas := new(big.Int).Add(orZero(op.VerificationGasLimit), orZero(op.CallGasLimit))
+	gas.Add(gas, orZero(op.PreVerificationGas))
+	gas.Add(gas, orZero(op.PaymasterVerificationGasLimit))
+
+	postOpGas := orZero(op.PaymasterPostOpGasLimit)
+	if q.PostOpGas != nil && q.PostOpGas.Cmp(postOpGas) > 0 {
+		postOpGas = q.PostOpGas
+	}
+	gas.Add(gas, postOpGas)
+
+	return q.price(gas, op.MaxFeePerGas)
+}
+
+// price converts gas at maxFeePerGas into token units.
+func (q *TokenQuote) price(gas *big.Int, maxFeePerGas *big.Int) *big.Int {
+	cost := new(big.Int).Mul(gas, orZero(maxFeePerGas))
+	cost.Mul(cost, orZero(q.ExchangeRate))
+
+	// Round up so the approval never falls short of the quoted cost.
+	cost.Add(cost, new(big.Int).Sub(exchangeRateScale, common.Big1))
+
+	return cost.Div(cost, exchangeRateScale)
+}
+
+// TokenPaymaster is a Paymaster that charges the smart account in an ERC-20 token. The
+// provider approves the quoted cost ahead of the userop's own calls when the paymaster's
+// allowance falls short.
+type TokenPaymaster interface {
+	Paymaster
+	// QuoteToken returns the paymaster's current token price.
+	QuoteToken(ctx context.Context, entryPoint common.Address, chainID *big.Int) (*TokenQuote, error)
+}
+
+// ERC20Paymaster is a TokenPaymaster for services that sponsor through ERC-7677 with a
+// token context and quote prices with pimlico_getTokenQuotes.
+type ERC20Paymaster struct {
+	*ERC7677Paymaster
+	token common.Address
+}
+
+// NewERC20Paymaster creates a token paymaster that charges in token. A nil httpClient uses
+// the default transport.
+func NewERC20Paymaster(url string, token common.Address, httpClient *http.Client) (*ERC20Paymaster, error) {
+	paymaster, err := NewERC7677Paymaster(url, map[string]any{"token": token}, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ERC20Paymaster{ERC7677Paymaster: paymaster, token: token}, nil
+}
+
+// tokenQuoteJSON is a single entry of the pimlico_getTokenQuotes response.
+type tokenQuoteJSON struct {
+	Paymaster    common.Address `json:"paymaster"`
+	Token        common.Address `json:"token"`
+	ExchangeRate *hexutil.Big   `json:"exchangeRate"`
+	PostOpGas    *hexutil.Big   `json:"postOpGas"`
+}
+
+func (p *ERC20Paymaster) forEntryPoint(version EntryPointVersion) (Paymaster, error) {
+	bound := *p.ERC7677Paymaster
+	bound.version = version
+
+	return &ERC20Paymaster{ERC7677Paymaster: &bound, token: p.token}, nil
+}
+
+// QuoteToken implements TokenPaymaster.
+func (p *ERC20Paymaster) QuoteToken(ctx context.Context, entryPoint common.Address, chainID *big.Int) (*TokenQuote, error) {
+	return quoteToken(ctx, p.client, p.token, entryPoint, chainID)
+}
+
+// quoteToken calls pimlico_getTokenQuotes for a single token.
+func quoteToken(ctx context.Context, client *rpc.Client, token common.Address, entryPoint common.Address, chainID *big.Int) (*TokenQuote, error) {
+	const method = "pimlico_getTokenQuotes"
+
+	var res struct {
+		Quotes []tokenQuoteJSON `json:"quotes"`
+	}
+	tokens := map[string]any{"tokens": []common.Address{token}}
+	if err := client.CallContext(ctx, &res, method, tokens, entryPoint, (*hexutil.Big)(chainID)); err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	for _, quote := range res.Quotes {
+		if quote.Token != token {
+			continue
+		}
+		if quote.ExchangeRate == nil {
+			return nil, fmt.Errorf("%s: quote for %s has no exchange rate", method, token)
+		}
+
+		return &TokenQuote{
+			Paymaster:    quote.Paymaster,
+			Token:        quote.Token,
+			ExchangeRate: quote.ExchangeRate.ToInt(),
+			PostOpGas:    (*big.Int)(quote.PostOpGas),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%s: paymaster does not accept token %s", method, token)
+}
+
+// tokenApproval tracks the approve call a token paymaster needs ahead of a userop's calls.
+type tokenApproval struct {
+	quote     *TokenQuote
+	allowance *big.Int          // The paymaster's current allowance from the smart account
+	version   EntryPointVersion // EntryPoint release the cost is computed for
+}
+
+// quoteTokenApproval fetches the token quote and current allowance, or returns nil when
+// the configured paymaster does not charge in a token.
+func (sap *SmartAccountProvider) quoteTokenApproval(ctx context.Context, sender common.Address) (*tokenApproval, error) {
+	paymaster, ok := sap.Paymaster.(TokenPaymaster)
+	if !ok {
+		return nil, nil
+	}
+
+	quote, err := paymaster.QuoteToken(ctx, common.HexToAddress(sap.Contracts.entrypoint), sap.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := erc20.NewERC20Caller(quote.Token, sap.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	allowance, err := token.Allowance(&bind.CallOpts{Context: ctx}, sender, quote.Paymaster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the token allowance: %w", err)
+	}
+
+	return &tokenApproval{quote: quote, allowance: allowance, version: sap.EntryPointVersion}, nil
+}
+
+// target returns the approve call for amount as a TargetParams.
+func (a *tokenApproval) target(amount *big.Int) (TargetParams, error) {
+	token, err := erc20.ERC20MetaData.GetAbi()
+	if err != nil {
+		return TargetParams{}, err
+	}
+
+	data, err := token.Pack("approve", a.quote.Paymaster, amount)
+	if err != nil {
+		return TargetParams{}, err
+	}
+
+	return TargetParams{Target: a.quote.Token.Hex(), Data: hexutil.Encode(data)}, nil
+}
+
+// cost returns the quoted cost of uo for the approval's EntryPoint version.
+func (a *tokenApproval) cost(uo *UserOperation) (*big.Int, error) {
+	if a.version != EntryPointV07 {
+		return a.quote.Cost(uo), nil
+	}
+
+	v07, err := uo.ToV07()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.quote.CostV07(v07), nil
+}
+
+// withApproval prepends an approve call for amount to targets.
+func (a *tokenApproval) withApproval(targets []TargetParams, amount *big.Int) ([]TargetParams, error) {
+	approve, err := a.target(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]TargetParams{approve}, targets...), nil
+}
+
+// settle returns the calls uo should make once its gas and fees are known, along with the
+// allowance the paymaster will have. The approve call is kept, set to the quoted cost, only
+// when the current allowance does not cover it.
+func (a *tokenApproval) settle(uo *UserOperation, targets []TargetParams) ([]TargetParams, *big.Int, error) {
+	cost, err := a.cost(uo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if a.allowance.Cmp(cost) >= 0 {
+		return targets, a.allowance, nil
+	}
+
+	calls, err := a.withApproval(targets, cost)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return calls, cost, nil
+}
+
+// applyTokenPaymasterData settles the token approval for uo's gas and applies the final
+// paymaster data. The paymaster signs over the call data, so the approval is set first; if
+// the paymaster then raises the gas limits beyond what was approved, the approval is
+// recomputed and the paymaster asked again. A second raise is an error.
+func (sap *SmartAccountProvider) applyTokenPaymasterData(ctx context.Context, uo *UserOperation, stub *PaymasterData, approval *tokenApproval, targets []TargetParams) error {
+	for attempt := 0; ; attempt++ {
+		calls, approved, err := approval.settle(uo, targets)
+		if err != nil {
+			return err
+		}
+
+		if uo.CallData, err = sap.encodeCalls(calls); err != nil {
+			return err
+		}
+
+		if err := sap.applyPaymasterData(ctx, uo, stub); err != nil {
+			return err
+		}
+
+		cost, err := approval.cost(uo)
+		if err != nil {
+			return err
+		}
+
+		if cost.Cmp(approved) <= 0 {
+			return nil
+		}
+
+		if attempt > 0 {
+			return fmt.Errorf("paymaster gas limits cost up to %s tokens, above the approved %s", cost, approved)
+		}
+	}
+}
+
+// TokenCharged returns the net amount of token that left the smart account in the
+// receipt's logs, which are the userop's own: transfers out of the account minus transfers
+// back into it, whatever the counterparty. Token paymasters commonly take a prefund during
+// validation and refund the unused part in postOp, sometimes routing tokens through a
+// separate treasury, so only the net reflects the charge. Token the op's own call sends or
+// receives is included as well.
+func TokenCharged(receipt *bundler.UserOperationReceipt, token common.Address) (*big.Int, error) {
+	if receipt == nil {
+		return nil, errors.New("nil receipt")
+	}
+
+	tokenABI, err := erc20.ERC20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := tokenABI.Events["Transfer"]
+	charged := new(big.Int)
+
+	for _, log := range receipt.Logs {
+		if log.Address != token || len(log.Topics) != 3 || log.Topics[0] != transfer.ID {
+			continue
+		}
+
+		from := common.BytesToAddress(log.Topics[1].Bytes())
+		to := common.BytesToAddress(log.Topics[2].Bytes())
+		if from != receipt.Sender && to != receipt.Sender {
+			continue
+		}
+
+		values, err := transfer.Inputs.NonIndexed().Unpack(log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Transfer log in %s: %w", log.TxHash, err)
+		}
+		value := values[0].(*big.Int)
+
+		if from == receipt.Sender {
+			charged.Add(charged, value)
+		}
+		if to == receipt.Sender {
+			charged.Sub(charged, value)
+		}
+	}
+
+	return charged, nil
+}
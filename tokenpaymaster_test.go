@@ -0,0 +1,229 @@
+package goaa
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pavankpdev/goaa/bundler"
+	account "github.com/pavankpdev/goaa/gen"
+	erc20 "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"testing"
+)
+
+// raisingPaymaster returns final data setting callGasLimit to limits[i] on its i-th call,
+// or leaving it unchanged once limits run out.
+type raisingPaymaster struct {
+	limits []int64
+	calls  int
+}
+
+func (p *raisingPaymaster) GetPaymasterStubData(context.Context, *UserOperation, common.Address, *big.Int) (*PaymasterData, error) {
+	return nil, nil
+}
+
+func (p *raisingPaymaster) GetPaymasterData(context.Context, *UserOperation, common.Address, *big.Int) (*PaymasterData, error) {
+	data := &PaymasterData{PaymasterAndData: []byte{0x01}}
+	if p.calls < len(p.limits) {
+		data.CallGasLimit = big.NewInt(p.limits[p.calls])
+	}
+	p.calls++
+
+	return data, nil
+}
+
+// approvedAmount decodes the approve amount from the first call of an executeBatch.
+func approvedAmount(t *testing.T, callData []byte) *big.Int {
+	t.Helper()
+
+	accountABI, err := account.SimpleAccountMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err := accountABI.Methods["executeBatch"].Inputs.Unpack(callData[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	approve := args[1].([][]byte)[0]
+
+	tokenABI, err := erc20.ERC20MetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := tokenABI.Methods["approve"].Inputs.Unpack(approve[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return values[1].(*big.Int)
+}
+
+func TestApplyTokenPaymasterDataCoversRaisedLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   []int64 // callGasLimit returned by each paymaster call
+		calls    int
+		approved int64
+		fails    bool
+	}{
+		{name: "limits kept", calls: 1, approved: 500},
+		{name: "limits raised once", limits: []int64{1000}, calls: 2, approved: 1400},
+		{name: "limits lowered", limits: []int64{50}, calls: 1, approved: 500},
+		{name: "limits raised twice", limits: []int64{1000, 2000}, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paymaster := &raisingPaymaster{limits: tt.limits}
+			sap := &SmartAccountProvider{
+				Paymaster: paymaster,
+				Contracts: &ContractAddressParams{entrypoint: CanonicalEntryPointV06.Hex()},
+				ChainID:   big.NewInt(1),
+			}
+			approval := &tokenApproval{
+				quote: &TokenQuote{
+					Paymaster:    common.HexToAddress("0xe93eca6595fe94091dc1af46aac2a8b5d7990770"),
+					Token:        common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238"),
+					ExchangeRate: exchangeRateScale,
+				},
+				allowance: new(big.Int),
+			}
+			uo := &UserOperation{
+				CallGasLimit:         big.NewInt(100),
+				VerificationGasLimit: big.NewInt(100),
+				PreVerificationGas:   big.NewInt(100),
+				MaxFeePerGas:         big.NewInt(1),
+			}
+			targets := []TargetParams{{Target: "0x94f3178AcB40d0E9c6967108e3711CF047D3240A"}}
+
+			err := sap.applyTokenPaymasterData(context.Background(), uo, nil, approval, targets)
+			if tt.fails {
+				if err == nil {
+					t.Fatal("expected an error when the paymaster raises the limits twice")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if paymaster.calls != tt.calls {
+				t.Errorf("paymaster called %d times, want %d", paymaster.calls, tt.calls)
+			}
+			if got := approvedAmount(t, uo.CallData); got.Int64() != tt.approved {
+				t.Errorf("approved %s, want %d", got, tt.approved)
+			}
+			if cost := approval.quote.Cost(uo); cost.Int64() > tt.approved {
+				t.Errorf("final cost %s exceeds the approval %d", cost, tt.approved)
+			}
+		})
+	}
+}
+
+func TestTokenQuoteCostV07(t *testing.T) {
+	quote := &TokenQuote{ExchangeRate: new(big.Int).Mul(exchangeRateScale, big.NewInt(2)), PostOpGas: big.NewInt(30)}
+
+	tests := []struct {
+		name                    string
+		paymasterPostOpGasLimit int64
+		want                    int64
+	}{
+		// (100 + 200 + 10 + 40 + 30) * 3 * 2, with the quote's postOp gas above the op's
+		{name: "quote postOp gas", paymasterPostOpGasLimit: 20, want: 2280},
+		// (100 + 200 + 10 + 40 + 50) * 3 * 2
+		{name: "op postOp gas limit", paymasterPostOpGasLimit: 50, want: 2400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := &UserOperationV07{
+				VerificationGasLimit:          big.NewInt(100),
+				CallGasLimit:                  big.NewInt(200),
+				PreVerificationGas:            big.NewInt(10),
+				MaxFeePerGas:                  big.NewInt(3),
+				PaymasterVerificationGasLimit: big.NewInt(40),
+				PaymasterPostOpGasLimit:       big.NewInt(tt.paymasterPostOpGasLimit),
+			}
+
+			if got := quote.CostV07(op); got.Int64() != tt.want {
+				t.Errorf("CostV07() = %s, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenCharged(t *testing.T) {
+	token := common.HexToAddress("0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238")
+	sender := common.HexToAddress("0x94f3178AcB40d0E9c6967108e3711CF047D3240A")
+	paymaster := common.HexToAddress("0xe93eca6595fe94091dc1af46aac2a8b5d7990770")
+	treasury := common.HexToAddress("0x1306b01bC3e4AD202612D3843387e94737673F53")
+
+	tokenABI, err := erc20.ERC20MetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer := tokenABI.Events["Transfer"]
+
+	transferLog := func(address, from, to common.Address, value int64) types.Log {
+		data, err := transfer.Inputs.NonIndexed().Pack(big.NewInt(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return types.Log{
+			Address: address,
+			Topics:  []common.Hash{transfer.ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:    data,
+		}
+	}
+
+	tests := []struct {
+		name string
+		logs []types.Log
+		want int64
+	}{
+		{
+			name: "charge",
+			logs: []types.Log{transferLog(token, sender, paymaster, 700)},
+			want: 700,
+		},
+		{
+			name: "prefund and refund",
+			logs: []types.Log{
+				transferLog(token, sender, paymaster, 1000),
+				transferLog(token, paymaster, sender, 300),
+			},
+			want: 700,
+		},
+		{
+			name: "treasury",
+			logs: []types.Log{
+				transferLog(token, sender, treasury, 1000),
+				transferLog(token, treasury, sender, 250),
+			},
+			want: 750,
+		},
+		{
+			name: "other token and accounts ignored",
+			logs: []types.Log{
+				transferLog(paymaster, sender, paymaster, 1000),
+				transferLog(token, paymaster, treasury, 1000),
+				transferLog(token, sender, paymaster, 5),
+			},
+			want: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := &bundler.UserOperationReceipt{Sender: sender, Paymaster: paymaster, Logs: tt.logs}
+
+			charged, err := TokenCharged(receipt, token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if charged.Int64() != tt.want {
+				t.Errorf("TokenCharged() = %s, want %d", charged, tt.want)
+			}
+		})
+	}
+}
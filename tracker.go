@@ -0,0 +1,162 @@
+package goaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	entrypoint "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"time"
+)
+
+// UserOperationOutcome is the on-chain result of a userop, read from the EntryPoint's
+// UserOperationEvent and, for failed ops, UserOperationRevertReason logs.
+type UserOperationOutcome struct {
+	UserOpHash    common.Hash
+	Sender        common.Address
+	Paymaster     common.Address // Zero when the op paid for itself
+	Nonce         *big.Int
+	Success       bool     // Whether the op's call succeeded
+	ActualGasCost *big.Int // Wei charged to the account or paymaster
+	ActualGasUsed *big.Int
+	TxHash        common.Hash // Bundle transaction that included the op
+	BlockNumber   uint64
+	RevertReason  []byte // Raw revert data of the failed call, if the EntryPoint logged any
+	RevertError   error  // RevertReason decoded into a RevertError or EntryPoint error
+}
+
+// FindUserOperation looks the userop up in the EntryPoint's logs from fromBlock onwards,
+// without involving the bundler. It returns nil and no error when the op has not been
+// included yet. Nodes may limit the range of eth_getLogs, so fromBlock should be close to
+// the block the op was sent at.
+func (sap *SmartAccountProvider) FindUserOperation(ctx context.Context, userOpHash common.Hash, fromBlock uint64) (*UserOperationOutcome, error) {
+	events, err := sap.EntryPoint.FilterUserOperationEvent(&bind.FilterOpts{Start: fromBlock, Context: ctx}, [][32]byte{userOpHash}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer events.Close()
+
+	if !events.Next() {
+		return nil, events.Error()
+	}
+
+	return sap.userOperationOutcome(ctx, events.Event)
+}
+
+// WaitForUserOperation waits until the userop appears in the EntryPoint's logs and returns
+// its outcome. It subscribes to UserOperationEvent when the node supports subscriptions and
+// polls eth_getLogs otherwise. It stops when ctx is cancelled or its deadline passes.
+func (sap *SmartAccountProvider) WaitForUserOperation(ctx context.Context, userOpHash common.Hash, fromBlock uint64) (*UserOperationOutcome, error) {
+	sink := make(chan *entrypoint.EntryPointUserOperationEvent)
+
+	sub, err := sap.EntryPoint.WatchUserOperationEvent(&bind.WatchOpts{Context: ctx}, sink, [][32]byte{userOpHash}, nil, nil)
+	if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		return sap.pollUserOperation(ctx, userOpHash, fromBlock)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	// The subscription only reports new logs, so check for an op included before it started.
+	outcome, err := sap.FindUserOperation(ctx, userOpHash, fromBlock)
+	if err != nil || outcome != nil {
+		return outcome, err
+	}
+
+	select {
+	case event := <-sink:
+		return sap.userOperationOutcome(ctx, event)
+	case err := <-sub.Err():
+		return nil, err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for userop %s: %w", userOpHash, ctx.Err())
+	}
+}
+
+// pollUserOperation polls FindUserOperation with the same backoff as
+// WaitForUserOperationReceipt.
+func (sap *SmartAccountProvider) pollUserOperation(ctx context.Context, userOpHash common.Hash, fromBlock uint64) (*UserOperationOutcome, error) {
+	delay := receiptPollInitial
+
+	for {
+		outcome, err := sap.FindUserOperation(ctx, userOpHash, fromBlock)
+		if err != nil || outcome != nil {
+			return outcome, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("waiting for userop %s: %w", userOpHash, ctx.Err())
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > receiptPollMax {
+			delay = receiptPollMax
+		}
+	}
+}
+
+// userOperationOutcome converts a UserOperationEvent into an outcome, joining in the
+// UserOperationRevertReason logged in the same block when the op failed.
+func (sap *SmartAccountProvider) userOperationOutcome(ctx context.Context, event *entrypoint.EntryPointUserOperationEvent) (*UserOperationOutcome, error) {
+	outcome := &UserOperationOutcome{
+		UserOpHash:    event.UserOpHash,
+		Sender:        event.Sender,
+		Paymaster:     event.Paymaster,
+		Nonce:         event.Nonce,
+		Success:       event.Success,
+		ActualGasCost: event.ActualGasCost,
+		ActualGasUsed: event.ActualGasUsed,
+		TxHash:        event.Raw.TxHash,
+		BlockNumber:   event.Raw.BlockNumber,
+	}
+
+	if outcome.Success {
+		return outcome, nil
+	}
+
+	block := event.Raw.BlockNumber
+	reasons, err := sap.EntryPoint.FilterUserOperationRevertReason(&bind.FilterOpts{Start: block, End: &block, Context: ctx}, [][32]byte{event.UserOpHash}, []common.Address{event.Sender})
+	if err != nil {
+		return nil, err
+	}
+	defer reasons.Close()
+
+	for reasons.Next() {
+		if reasons.Event.Raw.TxHash != event.Raw.TxHash {
+			continue
+		}
+		outcome.RevertReason = reasons.Event.RevertReason
+		outcome.RevertError = decodeRevertReason(reasons.Event.RevertReason)
+		return outcome, nil
+	}
+	if err := reasons.Error(); err != nil {
+		return nil, err
+	}
+
+	// Calls that run out of gas revert without the EntryPoint logging a reason.
+	outcome.RevertError = errors.New("userop call reverted without a logged reason")
+
+	return outcome, nil
+}
+
+// decodeRevertReason turns revert data into an error, preferring a decoded EntryPoint error
+// or Error(string) reason over the raw bytes.
+func decodeRevertReason(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("execution reverted without data")
+	}
+
+	if decoded := DecodeEntryPointError(data); decoded != nil {
+		return decoded
+	}
+
+	return fmt.Errorf("execution reverted with data %x", data)
+}
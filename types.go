@@ -2,17 +2,37 @@ package goaa
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pavankpdev/goaa/bundler"
 	entrypoint "github.com/pavankpdev/goaa/gen"
 	factory "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"net/http"
+	"sync"
 )
 
 // SmartAccountProviderParams stores the parameters required to initialize the SmartAccountProvider.
 type SmartAccountProviderParams struct {
-	OwnerPrivateKey            string // The private key of the Ethereum account
-	RPC                        string // The RPC endpoint for the Ethereum node
-	EntryPointAddress          string // The address of the entry point contract
-	SmartAccountFactoryAddress string // The address of the smart account factory contract
+	OwnerPrivateKey            string            // The hex encoded private key of the owner, used when Signer is nil
+	Signer                     Signer            // Optional signer for the owner, e.g. from a keystore or mnemonic
+	RPC                        string            // The RPC endpoint for the Ethereum node
+	Chain                      string            // Optional registry chain name, e.g. "sepolia", checked against the node's chain ID
+	EntryPointAddress          string            // The address of the entry point contract, defaults to the chain's one for EntryPointVersion, else the bundler's first supported one
+	EntryPointVersion          EntryPointVersion // Optional expected EntryPoint release, detected on-chain when empty
+	SmartAccountFactoryAddress string            // The address of the smart account factory contract, defaults to the chain's SimpleAccountFactory
+	NonceKey                   *big.Int          // Optional 192-bit EntryPoint nonce key, defaults to 0
+	AccountIndex               int64             // The factory salt of the smart account to send from, defaults to 0
+	AccountAddress             string            // Optional explicit smart account address, overrides the one derived from AccountIndex
+	BundlerURL                 string            // The ERC-4337 bundler endpoint, defaults to RPC when the node exposes the bundler namespace
+	BundlerHeaders             map[string]string // Optional headers, e.g. Authorization, sent with every bundler request
+	HTTPClient                 *http.Client      // Optional HTTP client used for both the node and the bundler
+	GasMultipliers             GasMultipliers    // Safety margins applied to the bundler's gas estimates
+	FeeSpeed                   FeeSpeed          // Pricing tier of the built-in fee oracle, defaults to FeeStandard
+	MaxFeeCap                  *big.Int          // Optional hard cap on maxFeePerGas for the built-in fee oracle
+	FeeOracle                  FeeOracle         // Optional custom fee oracle, e.g. FixedFeeOracle, replacing the built-in one
+	Paymaster                  Paymaster         // Optional paymaster sponsoring every userop
+	VerifyUserOpHash           bool              // Cross-check every userOpHash with EntryPoint.getUserOpHash, costing an eth_call per send
 }
 
 type ContractAddressParams struct {
@@ -22,37 +42,114 @@ type ContractAddressParams struct {
 
 // SmartAccountProvider is a struct that manages interaction with Ethereum smart contracts.
 type SmartAccountProvider struct {
-	Client     *ethclient.Client      // Ethereum client for interacting with the blockchain
-	Owner      common.Address         // Ethereum address of the owner
-	SAFactory  *factory.Factory       // Smart account factory contract instance
-	EntryPoint *entrypoint.EntryPoint // Smart account factory contract instance
-	PrivateKey string                 // The private key of the Ethereum account
-	Contracts  *ContractAddressParams // The object that contains all the contract addresses
+	Client            *ethclient.Client         // Ethereum client for interacting with the blockchain
+	Owner             common.Address            // Ethereum address of the owner
+	SAFactory         *factory.Factory          // Smart account factory contract instance
+	EntryPoint        *entrypoint.EntryPoint    // Smart account factory contract instance
+	EntryPointV07     *entrypoint.EntryPointV07 // v0.7 EntryPoint binding, nil unless EntryPointVersion is EntryPointV07
+	EntryPointVersion EntryPointVersion         // The EntryPoint release userops are built for
+	Bundler           *bundler.Client           // ERC-4337 bundler JSON-RPC client
+	Signer            Signer                    // Signs userOpHashes on behalf of the owner
+	Contracts         *ContractAddressParams    // The object that contains all the contract addresses
+	ChainID           *big.Int                  // Chain ID reported by the node, bound into every userOpHash
+	NonceKey          *big.Int                  // EntryPoint nonce key used for every userop sent by this provider
+	GasMultipliers    GasMultipliers            // Safety margins applied to the bundler's gas estimates
+	FeeOracle         FeeOracle                 // Source of maxFeePerGas and maxPriorityFeePerGas
+	Paymaster         Paymaster                 // Optional paymaster filling paymasterAndData, nil when the account pays
+	Account           common.Address            // The smart account every userop is sent from
+	Salt              *big.Int                  // The factory salt the smart account is (or will be) deployed with
+	VerifyUserOpHash  bool                      // Whether userOpHashes are cross-checked on-chain before signing
+
+	deployedMu sync.Mutex              // Guards deployed
+	deployed   map[common.Address]bool // Smart accounts already known to have code on chain
 }
 
+// TargetParams describes a single call made by the smart account.
 type TargetParams struct {
-	Target string
-	Data   string
-	Value  string
+	Target string // The address of the contract or account being called
+	Data   string // Hex encoded call data, "0x" or empty for a plain transfer
+	Value  string // Amount of wei to send, as a decimal or 0x-prefixed hex string
+}
+
+// UserOperation is an ERC-4337 v0.6 user operation. Its fields match gen.UserOperation, so
+// the two convert losslessly with ToEntryPoint and UserOperationFromEntryPoint, while its
+// JSON form is the hex encoded shape expected by bundlers.
+type UserOperation struct {
+	Sender               common.Address // The smart account sending the op
+	Nonce                *big.Int       // EntryPoint nonce, 192-bit key followed by a 64-bit sequence
+	InitCode             []byte         // Factory address and call data, empty once the account is deployed
+	CallData             []byte         // Call executed by the account
+	CallGasLimit         *big.Int       // Gas available to the execution phase
+	VerificationGasLimit *big.Int       // Gas available to deployment and validation
+	PreVerificationGas   *big.Int       // Gas paid to the bundler for calldata and overhead
+	MaxFeePerGas         *big.Int       // Upper bound on the total price per gas
+	MaxPriorityFeePerGas *big.Int       // Tip per gas paid on top of the base fee
+	PaymasterAndData     []byte         // Paymaster address and data, empty when the account pays
+	Signature            []byte         // Signature checked by the account's validateUserOp
 }
 
-type UOps struct {
+// userOperationJSON is the bundler JSON-RPC representation of a UserOperation.
+type userOperationJSON struct {
 	Sender               common.Address `json:"sender"`
-	Nonce                string         `json:"nonce"`
-	InitCode             string         `json:"initCode"`
-	CallData             string         `json:"callData"`
-	Signature            *common.Hash   `json:"signature,omitempty"`
-	CallGasLimit         string         `json:"callGasLimit"`
-	VerificationGasLimit string         `json:"verificationGasLimit"`
-	PreVerificationGas   string         `json:"preVerificationGas"`
-	MaxFeePerGas         string         `json:"maxFeePerGas"`
-	MaxPriorityFeePerGas string         `json:"maxPriorityFeePerGas"`
-	PaymasterAndData     string         `json:"paymasterAndData"`
-}
-
-type UserOperationTxnPayload struct {
-	Id      int64  `json:"id"`
-	Jsonrpc string `json:"jsonrpc"`
-	Method  string `json:"method"`
-	Params  []any  `json:"params"`
+	Nonce                *hexutil.Big   `json:"nonce"`
+	InitCode             hexutil.Bytes  `json:"initCode"`
+	CallData             hexutil.Bytes  `json:"callData"`
+	CallGasLimit         *hexutil.Big   `json:"callGasLimit"`
+	VerificationGasLimit *hexutil.Big   `json:"verificationGasLimit"`
+	PreVerificationGas   *hexutil.Big   `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     hexutil.Bytes  `json:"paymasterAndData"`
+	Signature            hexutil.Bytes  `json:"signature"`
+}
+
+// EntryPointVersion selects the ERC-4337 EntryPoint release a provider targets. It also
+// picks the userop format paymaster services receive: with EntryPointV07 they get and may
+// return the separate paymaster fields, which the provider packs into paymasterAndData.
+// VerifyingPaymaster only supports EntryPointV06.
+type EntryPointVersion string
+
+const (
+	EntryPointV06 EntryPointVersion = "0.6" // UserOperation layout, the default
+	EntryPointV07 EntryPointVersion = "0.7" // PackedUserOperation layout
+)
+
+// UserOperationV07 is an ERC-4337 v0.7 user operation in its unpacked form, as exchanged
+// with bundlers. Pack converts it into the PackedUserOperation the EntryPoint consumes.
+type UserOperationV07 struct {
+	Sender                        common.Address  // The smart account sending the op
+	Nonce                         *big.Int        // EntryPoint nonce, 192-bit key followed by a 64-bit sequence
+	Factory                       *common.Address // Factory deploying the account, nil once it is deployed
+	FactoryData                   []byte          // Call data sent to the factory
+	CallData                      []byte          // Call executed by the account
+	CallGasLimit                  *big.Int        // Gas available to the execution phase
+	VerificationGasLimit          *big.Int        // Gas available to deployment and account validation
+	PreVerificationGas            *big.Int        // Gas paid to the bundler for calldata and overhead
+	MaxFeePerGas                  *big.Int        // Upper bound on the total price per gas
+	MaxPriorityFeePerGas          *big.Int        // Tip per gas paid on top of the base fee
+	Paymaster                     *common.Address // Paymaster sponsoring the op, nil when the account pays
+	PaymasterVerificationGasLimit *big.Int        // Gas available to the paymaster's validation
+	PaymasterPostOpGasLimit       *big.Int        // Gas available to the paymaster's postOp
+	PaymasterData                 []byte          // Paymaster specific data
+	Signature                     []byte          // Signature checked by the account's validateUserOp
+}
+
+// userOperationV07JSON is the bundler JSON-RPC representation of a UserOperationV07.
+// Factory and paymaster fields are omitted when unset.
+type userOperationV07JSON struct {
+	Sender                        common.Address  `json:"sender"`
+	Nonce                         *hexutil.Big    `json:"nonce"`
+	Factory                       *common.Address `json:"factory,omitempty"`
+	FactoryData                   hexutil.Bytes   `json:"factoryData,omitempty"`
+	CallData                      hexutil.Bytes   `json:"callData"`
+	CallGasLimit                  *hexutil.Big    `json:"callGasLimit"`
+	VerificationGasLimit          *hexutil.Big    `json:"verificationGasLimit"`
+	PreVerificationGas            *hexutil.Big    `json:"preVerificationGas"`
+	MaxFeePerGas                  *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas          *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Paymaster                     *common.Address `json:"paymaster,omitempty"`
+	PaymasterVerificationGasLimit *hexutil.Big    `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       *hexutil.Big    `json:"paymasterPostOpGasLimit,omitempty"`
+	PaymasterData                 hexutil.Bytes   `json:"paymasterData,omitempty"`
+	Signature                     hexutil.Bytes   `json:"signature"`
 }
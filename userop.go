@@ -0,0 +1,172 @@
+package goaa
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	entrypoint "github.com/pavankpdev/goaa/gen"
+	"math/big"
+)
+
+var (
+	uint256Type, _ = abi.NewType("uint256", "", nil)
+	addressType, _ = abi.NewType("address", "", nil)
+	bytes32Type, _ = abi.NewType("bytes32", "", nil)
+
+	// userOpPackArgs mirrors the layout hashed by UserOperationLib.pack in EntryPoint v0.6.
+	userOpPackArgs = abi.Arguments{
+		{Type: addressType},
+		{Type: uint256Type},
+		{Type: bytes32Type},
+		{Type: bytes32Type},
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: bytes32Type},
+	}
+
+	// userOpHashArgs binds the packed userop hash to an entry point and chain.
+	userOpHashArgs = abi.Arguments{
+		{Type: bytes32Type},
+		{Type: addressType},
+		{Type: uint256Type},
+	}
+)
+
+// GetUserOpHash computes the EntryPoint v0.6 userOpHash locally. It matches the value
+// returned by EntryPoint.getUserOpHash for the same op, entry point and chain ID.
+func GetUserOpHash(op *UserOperation, entryPoint common.Address, chainID *big.Int) (common.Hash, error) {
+	packed, err := userOpPackArgs.Pack(
+		op.Sender,
+		orZero(op.Nonce),
+		crypto.Keccak256Hash(op.InitCode),
+		crypto.Keccak256Hash(op.CallData),
+		orZero(op.CallGasLimit),
+		orZero(op.VerificationGasLimit),
+		orZero(op.PreVerificationGas),
+		orZero(op.MaxFeePerGas),
+		orZero(op.MaxPriorityFeePerGas),
+		crypto.Keccak256Hash(op.PaymasterAndData),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	encoded, err := userOpHashArgs.Pack(crypto.Keccak256Hash(packed), entryPoint, chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// ToEntryPoint converts op into the struct used by the generated EntryPoint binding, e.g.
+// for HandleOps or GetUserOpHash. The result shares op's big.Int and byte slice values.
+func (op *UserOperation) ToEntryPoint() entrypoint.UserOperation {
+	return entrypoint.UserOperation(*op)
+}
+
+// UserOperationFromEntryPoint converts a userop decoded by the generated EntryPoint binding.
+func UserOperationFromEntryPoint(op entrypoint.UserOperation) *UserOperation {
+	uo := UserOperation(op)
+	return &uo
+}
+
+// Copy returns a deep copy of op.
+func (op *UserOperation) Copy() *UserOperation {
+	return &UserOperation{
+		Sender:               op.Sender,
+		Nonce:                copyBig(op.Nonce),
+		InitCode:             common.CopyBytes(op.InitCode),
+		CallData:             common.CopyBytes(op.CallData),
+		CallGasLimit:         copyBig(op.CallGasLimit),
+		VerificationGasLimit: copyBig(op.VerificationGasLimit),
+		PreVerificationGas:   copyBig(op.PreVerificationGas),
+		MaxFeePerGas:         copyBig(op.MaxFeePerGas),
+		MaxPriorityFeePerGas: copyBig(op.MaxPriorityFeePerGas),
+		PaymasterAndData:     common.CopyBytes(op.PaymasterAndData),
+		Signature:            common.CopyBytes(op.Signature),
+	}
+}
+
+// MarshalJSON encodes op in the bundler JSON-RPC format. Nil quantities encode as 0x0 and
+// nil byte fields as 0x.
+func (op UserOperation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(userOperationJSON{
+		Sender:               op.Sender,
+		Nonce:                hexBig(op.Nonce),
+		InitCode:             hexBytes(op.InitCode),
+		CallData:             hexBytes(op.CallData),
+		CallGasLimit:         hexBig(op.CallGasLimit),
+		VerificationGasLimit: hexBig(op.VerificationGasLimit),
+		PreVerificationGas:   hexBig(op.PreVerificationGas),
+		MaxFeePerGas:         hexBig(op.MaxFeePerGas),
+		MaxPriorityFeePerGas: hexBig(op.MaxPriorityFeePerGas),
+		PaymasterAndData:     hexBytes(op.PaymasterAndData),
+		Signature:            hexBytes(op.Signature),
+	})
+}
+
+// UnmarshalJSON decodes op from the bundler JSON-RPC format.
+func (op *UserOperation) UnmarshalJSON(input []byte) error {
+	var dec userOperationJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	*op = UserOperation{
+		Sender:               dec.Sender,
+		Nonce:                (*big.Int)(dec.Nonce),
+		InitCode:             dec.InitCode,
+		CallData:             dec.CallData,
+		CallGasLimit:         (*big.Int)(dec.CallGasLimit),
+		VerificationGasLimit: (*big.Int)(dec.VerificationGasLimit),
+		PreVerificationGas:   (*big.Int)(dec.PreVerificationGas),
+		MaxFeePerGas:         (*big.Int)(dec.MaxFeePerGas),
+		MaxPriorityFeePerGas: (*big.Int)(dec.MaxPriorityFeePerGas),
+		PaymasterAndData:     dec.PaymasterAndData,
+		Signature:            dec.Signature,
+	}
+
+	return nil
+}
+
+// hexBig converts a possibly nil quantity into its JSON form, treating nil as zero.
+func hexBig(v *big.Int) *hexutil.Big {
+	if v == nil {
+		return (*hexutil.Big)(new(big.Int))
+	}
+
+	return (*hexutil.Big)(v)
+}
+
+// hexBytes converts a possibly nil byte slice into its JSON form, treating nil as empty.
+func hexBytes(b []byte) hexutil.Bytes {
+	if b == nil {
+		return hexutil.Bytes{}
+	}
+
+	return b
+}
+
+// orZero returns v, or zero if v is nil.
+func orZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+
+	return v
+}
+
+// copyBig returns a copy of v, or nil if v is nil.
+func copyBig(v *big.Int) *big.Int {
+	if v == nil {
+		return nil
+	}
+
+	return new(big.Int).Set(v)
+}
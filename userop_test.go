@@ -0,0 +1,247 @@
+package goaa
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"testing"
+)
+
+// word left-pads b to a 32-byte ABI word, the way abi.encode lays out static values.
+func word(b []byte) []byte {
+	return common.LeftPadBytes(b, 32)
+}
+
+// testUserOp returns a v0.6 userop with every field set to a distinct value.
+func testUserOp() *UserOperation {
+	return &UserOperation{
+		Sender:               common.HexToAddress("0x1306b01bC3e4AD202612D3843387e94737673F53"),
+		Nonce:                big.NewInt(8942),
+		InitCode:             hexutil.MustDecode("0x9406cc6185a346906296840746125a0e449764545fbfb9cf"),
+		CallData:             hexutil.MustDecode("0xb61d27f6000000000000000000000000"),
+		CallGasLimit:         big.NewInt(35000),
+		VerificationGasLimit: big.NewInt(70000),
+		PreVerificationGas:   big.NewInt(21000),
+		MaxFeePerGas:         big.NewInt(3000000000),
+		MaxPriorityFeePerGas: big.NewInt(1500000000),
+		PaymasterAndData:     hexutil.MustDecode("0xe93eca6595fe94091dc1af46aac2a8b5d7990770"),
+		Signature:            dummySignature,
+	}
+}
+
+func TestGetUserOpHash(t *testing.T) {
+	entryPoint := CanonicalEntryPointV06
+	chainID := big.NewInt(11155111)
+
+	tests := []struct {
+		name string
+		op   *UserOperation
+		want common.Hash
+	}{
+		{
+			name: "all fields",
+			op:   testUserOp(),
+			want: common.HexToHash("0xb602c26c13986aff25c8c3048f72a192f6e45ec8480c010341a4db22033b3018"),
+		},
+		{
+			name: "empty op",
+			op:   &UserOperation{},
+			want: common.HexToHash("0xc1c44d3e53069a64547629efa27a312c9b908acc546317453f0f914da697cd1d"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetUserOpHash(tt.op, entryPoint, chainID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Rebuild the digest word by word, as UserOperationLib.pack and
+			// EntryPoint.getUserOpHash lay it out.
+			op := tt.op
+			var packed []byte
+			for _, w := range [][]byte{
+				word(op.Sender.Bytes()),
+				word(orZero(op.Nonce).Bytes()),
+				crypto.Keccak256(op.InitCode),
+				crypto.Keccak256(op.CallData),
+				word(orZero(op.CallGasLimit).Bytes()),
+				word(orZero(op.VerificationGasLimit).Bytes()),
+				word(orZero(op.PreVerificationGas).Bytes()),
+				word(orZero(op.MaxFeePerGas).Bytes()),
+				word(orZero(op.MaxPriorityFeePerGas).Bytes()),
+				crypto.Keccak256(op.PaymasterAndData),
+			} {
+				packed = append(packed, w...)
+			}
+			manual := crypto.Keccak256Hash(crypto.Keccak256(packed), word(entryPoint.Bytes()), word(chainID.Bytes()))
+
+			if got != manual {
+				t.Errorf("GetUserOpHash = %s, manual encoding gives %s", got, manual)
+			}
+			if got != tt.want {
+				t.Errorf("GetUserOpHash = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetUserOpHashIgnoresSignature(t *testing.T) {
+	op := testUserOp()
+	chainID := big.NewInt(1)
+
+	want, err := GetUserOpHash(op, CanonicalEntryPointV06, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op.Signature = []byte{0x01}
+	got, err := GetUserOpHash(op, CanonicalEntryPointV06, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Errorf("hash changed with the signature: %s != %s", got, want)
+	}
+
+	other, err := GetUserOpHash(op, CanonicalEntryPointV06, big.NewInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if other == want {
+		t.Error("hash does not depend on the chain ID")
+	}
+}
+
+// assertUserOpsEqual compares every field of got and want, treating nil and zero quantities
+// and nil and empty byte fields alike.
+func assertUserOpsEqual(t *testing.T, got *UserOperation, want *UserOperation) {
+	t.Helper()
+
+	if got.Sender != want.Sender {
+		t.Errorf("sender = %s, want %s", got.Sender, want.Sender)
+	}
+
+	quantities := []struct {
+		name      string
+		got, want *big.Int
+	}{
+		{"nonce", got.Nonce, want.Nonce},
+		{"callGasLimit", got.CallGasLimit, want.CallGasLimit},
+		{"verificationGasLimit", got.VerificationGasLimit, want.VerificationGasLimit},
+		{"preVerificationGas", got.PreVerificationGas, want.PreVerificationGas},
+		{"maxFeePerGas", got.MaxFeePerGas, want.MaxFeePerGas},
+		{"maxPriorityFeePerGas", got.MaxPriorityFeePerGas, want.MaxPriorityFeePerGas},
+	}
+	for _, q := range quantities {
+		if orZero(q.got).Cmp(orZero(q.want)) != 0 {
+			t.Errorf("%s = %s, want %s", q.name, q.got, q.want)
+		}
+	}
+
+	fields := []struct {
+		name      string
+		got, want []byte
+	}{
+		{"initCode", got.InitCode, want.InitCode},
+		{"callData", got.CallData, want.CallData},
+		{"paymasterAndData", got.PaymasterAndData, want.PaymasterAndData},
+		{"signature", got.Signature, want.Signature},
+	}
+	for _, f := range fields {
+		if !bytes.Equal(f.got, f.want) {
+			t.Errorf("%s = %x, want %x", f.name, f.got, f.want)
+		}
+	}
+}
+
+func TestUserOperationJSON(t *testing.T) {
+	op := testUserOp()
+	op.Signature = hexutil.MustDecode("0xabcd")
+
+	tests := []struct {
+		name string
+		op   *UserOperation
+		want string
+	}{
+		{
+			name: "all fields",
+			op:   op,
+			want: `{"sender":"0x1306b01bc3e4ad202612d3843387e94737673f53","nonce":"0x22ee",` +
+				`"initCode":"0x9406cc6185a346906296840746125a0e449764545fbfb9cf","callData":"0xb61d27f6000000000000000000000000",` +
+				`"callGasLimit":"0x88b8","verificationGasLimit":"0x11170","preVerificationGas":"0x5208",` +
+				`"maxFeePerGas":"0xb2d05e00","maxPriorityFeePerGas":"0x59682f00",` +
+				`"paymasterAndData":"0xe93eca6595fe94091dc1af46aac2a8b5d7990770","signature":"0xabcd"}`,
+		},
+		{
+			name: "nil and empty fields",
+			op:   &UserOperation{InitCode: []byte{}, Nonce: new(big.Int)},
+			want: `{"sender":"0x0000000000000000000000000000000000000000","nonce":"0x0",` +
+				`"initCode":"0x","callData":"0x",` +
+				`"callGasLimit":"0x0","verificationGasLimit":"0x0","preVerificationGas":"0x0",` +
+				`"maxFeePerGas":"0x0","maxPriorityFeePerGas":"0x0",` +
+				`"paymasterAndData":"0x","signature":"0x"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := json.Marshal(tt.op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(encoded) != tt.want {
+				t.Errorf("MarshalJSON =\n%s\nwant\n%s", encoded, tt.want)
+			}
+
+			// The value form must encode the same way, as it does inside params slices.
+			if byValue, err := json.Marshal(*tt.op); err != nil || string(byValue) != tt.want {
+				t.Errorf("MarshalJSON by value = %s, %v", byValue, err)
+			}
+
+			var decoded UserOperation
+			if err := json.Unmarshal([]byte(tt.want), &decoded); err != nil {
+				t.Fatal(err)
+			}
+			assertUserOpsEqual(t, &decoded, tt.op)
+
+			reencoded, err := json.Marshal(&decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(reencoded) != tt.want {
+				t.Errorf("round trip encodes as\n%s\nwant\n%s", reencoded, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserOperationEntryPointRoundTrip(t *testing.T) {
+	op := testUserOp()
+
+	converted := op.ToEntryPoint()
+	if converted.Sender != op.Sender || converted.Nonce.Cmp(op.Nonce) != 0 || !bytes.Equal(converted.Signature, op.Signature) {
+		t.Errorf("ToEntryPoint = %+v, want the fields of %+v", converted, op)
+	}
+
+	assertUserOpsEqual(t, UserOperationFromEntryPoint(converted), op)
+
+	// The binding hashes the same struct the provider signs over.
+	want, err := GetUserOpHash(op, CanonicalEntryPointV06, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := GetUserOpHash(UserOperationFromEntryPoint(converted), CanonicalEntryPointV06, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("round trip changed the hash: %s != %s", got, want)
+	}
+}
@@ -0,0 +1,235 @@
+package goaa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	entrypoint "github.com/pavankpdev/goaa/gen"
+	"math/big"
+)
+
+// gasLimitBytes is the width of each value in the packed 32-byte v0.7 gas fields and of the
+// paymaster gas limits in paymasterAndData.
+const gasLimitBytes = 16
+
+// paymasterFieldsLength is the length of the v0.7 paymasterAndData prefix: the paymaster
+// address followed by its verification and postOp gas limits.
+const paymasterFieldsLength = common.AddressLength + 2*gasLimitBytes
+
+// packedUserOpArgs mirrors the layout hashed by UserOperationLib.encode in EntryPoint v0.7.
+var packedUserOpArgs = abi.Arguments{
+	{Type: addressType},
+	{Type: uint256Type},
+	{Type: bytes32Type},
+	{Type: bytes32Type},
+	{Type: bytes32Type},
+	{Type: uint256Type},
+	{Type: bytes32Type},
+	{Type: bytes32Type},
+}
+
+// GetUserOpHashV07 computes the EntryPoint v0.7 userOpHash locally. It matches the value
+// returned by EntryPoint.getUserOpHash for the packed op, entry point and chain ID.
+func GetUserOpHashV07(op *UserOperationV07, entryPoint common.Address, chainID *big.Int) (common.Hash, error) {
+	packed, err := op.Pack()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	encoded, err := packedUserOpArgs.Pack(
+		packed.Sender,
+		orZero(packed.Nonce),
+		crypto.Keccak256Hash(packed.InitCode),
+		crypto.Keccak256Hash(packed.CallData),
+		packed.AccountGasLimits,
+		orZero(packed.PreVerificationGas),
+		packed.GasFees,
+		crypto.Keccak256Hash(packed.PaymasterAndData),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	hashed, err := userOpHashArgs.Pack(crypto.Keccak256Hash(encoded), entryPoint, chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash(hashed), nil
+}
+
+// Pack converts op into the PackedUserOperation used by the generated v0.7 EntryPoint
+// binding and hashed into the userOpHash. Gas values must fit in 128 bits.
+func (op *UserOperationV07) Pack() (entrypoint.PackedUserOperation, error) {
+	accountGasLimits, err := packUint128Pair(op.VerificationGasLimit, op.CallGasLimit)
+	if err != nil {
+		return entrypoint.PackedUserOperation{}, err
+	}
+
+	gasFees, err := packUint128Pair(op.MaxPriorityFeePerGas, op.MaxFeePerGas)
+	if err != nil {
+		return entrypoint.PackedUserOperation{}, err
+	}
+
+	initCode := []byte{}
+	if op.Factory != nil {
+		initCode = append(op.Factory.Bytes(), op.FactoryData...)
+	}
+
+	paymasterAndData := []byte{}
+	if op.Paymaster != nil {
+		if paymasterAndData, err = packPaymasterAndData(*op.Paymaster, op.PaymasterVerificationGasLimit, op.PaymasterPostOpGasLimit, op.PaymasterData); err != nil {
+			return entrypoint.PackedUserOperation{}, err
+		}
+	}
+
+	return entrypoint.PackedUserOperation{
+		Sender:             op.Sender,
+		Nonce:              orZero(op.Nonce),
+		InitCode:           initCode,
+		CallData:           hexBytes(op.CallData),
+		AccountGasLimits:   accountGasLimits,
+		PreVerificationGas: orZero(op.PreVerificationGas),
+		GasFees:            gasFees,
+		PaymasterAndData:   paymasterAndData,
+		Signature:          hexBytes(op.Signature),
+	}, nil
+}
+
+// ToV07 converts op into the v0.7 layout. InitCode splits into the factory and its data, and
+// PaymasterAndData must use the v0.7 layout: the paymaster, its 16-byte verification and
+// postOp gas limits, then the paymaster data.
+func (op *UserOperation) ToV07() (*UserOperationV07, error) {
+	v07 := &UserOperationV07{
+		Sender:               op.Sender,
+		Nonce:                op.Nonce,
+		CallData:             op.CallData,
+		CallGasLimit:         op.CallGasLimit,
+		VerificationGasLimit: op.VerificationGasLimit,
+		PreVerificationGas:   op.PreVerificationGas,
+		MaxFeePerGas:         op.MaxFeePerGas,
+		MaxPriorityFeePerGas: op.MaxPriorityFeePerGas,
+		Signature:            op.Signature,
+	}
+
+	if len(op.InitCode) > 0 {
+		if len(op.InitCode) < common.AddressLength {
+			return nil, errors.New("initCode is shorter than a factory address")
+		}
+		factory := common.BytesToAddress(op.InitCode[:common.AddressLength])
+		v07.Factory = &factory
+		v07.FactoryData = op.InitCode[common.AddressLength:]
+	}
+
+	if len(op.PaymasterAndData) > 0 {
+		if len(op.PaymasterAndData) < paymasterFieldsLength {
+			return nil, errors.New("v0.7 paymasterAndData must hold the paymaster address and both paymaster gas limits")
+		}
+		paymaster := common.BytesToAddress(op.PaymasterAndData[:common.AddressLength])
+		v07.Paymaster = &paymaster
+		v07.PaymasterVerificationGasLimit, v07.PaymasterPostOpGasLimit = paymasterGasLimits(op.PaymasterAndData)
+		v07.PaymasterData = op.PaymasterAndData[paymasterFieldsLength:]
+	}
+
+	return v07, nil
+}
+
+// MarshalJSON encodes op in the v0.7 bundler JSON-RPC format. Nil quantities encode as 0x0
+// and the factory and paymaster fields are left out when unset.
+func (op UserOperationV07) MarshalJSON() ([]byte, error) {
+	enc := userOperationV07JSON{
+		Sender:               op.Sender,
+		Nonce:                hexBig(op.Nonce),
+		CallData:             hexBytes(op.CallData),
+		CallGasLimit:         hexBig(op.CallGasLimit),
+		VerificationGasLimit: hexBig(op.VerificationGasLimit),
+		PreVerificationGas:   hexBig(op.PreVerificationGas),
+		MaxFeePerGas:         hexBig(op.MaxFeePerGas),
+		MaxPriorityFeePerGas: hexBig(op.MaxPriorityFeePerGas),
+		Signature:            hexBytes(op.Signature),
+	}
+
+	if op.Factory != nil {
+		enc.Factory = op.Factory
+		enc.FactoryData = hexBytes(op.FactoryData)
+	}
+
+	if op.Paymaster != nil {
+		enc.Paymaster = op.Paymaster
+		enc.PaymasterVerificationGasLimit = hexBig(op.PaymasterVerificationGasLimit)
+		enc.PaymasterPostOpGasLimit = hexBig(op.PaymasterPostOpGasLimit)
+		enc.PaymasterData = hexBytes(op.PaymasterData)
+	}
+
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON decodes op from the v0.7 bundler JSON-RPC format.
+func (op *UserOperationV07) UnmarshalJSON(input []byte) error {
+	var dec userOperationV07JSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	*op = UserOperationV07{
+		Sender:                        dec.Sender,
+		Nonce:                         (*big.Int)(dec.Nonce),
+		Factory:                       dec.Factory,
+		FactoryData:                   dec.FactoryData,
+		CallData:                      dec.CallData,
+		CallGasLimit:                  (*big.Int)(dec.CallGasLimit),
+		VerificationGasLimit:          (*big.Int)(dec.VerificationGasLimit),
+		PreVerificationGas:            (*big.Int)(dec.PreVerificationGas),
+		MaxFeePerGas:                  (*big.Int)(dec.MaxFeePerGas),
+		MaxPriorityFeePerGas:          (*big.Int)(dec.MaxPriorityFeePerGas),
+		Paymaster:                     dec.Paymaster,
+		PaymasterVerificationGasLimit: (*big.Int)(dec.PaymasterVerificationGasLimit),
+		PaymasterPostOpGasLimit:       (*big.Int)(dec.PaymasterPostOpGasLimit),
+		PaymasterData:                 dec.PaymasterData,
+		Signature:                     dec.Signature,
+	}
+
+	return nil
+}
+
+// packUint128Pair packs two 128-bit values into one 32-byte word, high then low, as used for
+// accountGasLimits, gasFees and the paymaster gas limits.
+func packUint128Pair(high *big.Int, low *big.Int) ([32]byte, error) {
+	var word [32]byte
+
+	for i, v := range []*big.Int{orZero(high), orZero(low)} {
+		if v.Sign() < 0 || v.BitLen() > 8*gasLimitBytes {
+			return word, fmt.Errorf("gas value %s does not fit in 128 bits", v)
+		}
+		v.FillBytes(word[i*gasLimitBytes : (i+1)*gasLimitBytes])
+	}
+
+	return word, nil
+}
+
+// packPaymasterAndData builds v0.7 paymasterAndData: the paymaster, its verification and
+// postOp gas limits as 16-byte values, then its data.
+func packPaymasterAndData(paymaster common.Address, verificationGasLimit *big.Int, postOpGasLimit *big.Int, data []byte) ([]byte, error) {
+	limits, err := packUint128Pair(verificationGasLimit, postOpGasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(paymaster.Bytes(), limits[:]...), data...), nil
+}
+
+// paymasterGasLimits reads the paymaster verification and postOp gas limits from v0.7
+// paymasterAndData, or returns nils when it is too short to hold them.
+func paymasterGasLimits(paymasterAndData []byte) (*big.Int, *big.Int) {
+	if len(paymasterAndData) < paymasterFieldsLength {
+		return nil, nil
+	}
+
+	verificationGasLimit := new(big.Int).SetBytes(paymasterAndData[common.AddressLength : common.AddressLength+gasLimitBytes])
+	postOpGasLimit := new(big.Int).SetBytes(paymasterAndData[common.AddressLength+gasLimitBytes : paymasterFieldsLength])
+
+	return verificationGasLimit, postOpGasLimit
+}
@@ -0,0 +1,156 @@
+package goaa
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"testing"
+)
+
+// testUserOpV07 returns a v0.7 userop with every field set to a distinct value.
+func testUserOpV07() *UserOperationV07 {
+	factory := CanonicalSimpleAccountFactoryV07
+	paymaster := common.HexToAddress("0xe93eca6595fe94091dc1af46aac2a8b5d7990770")
+
+	return &UserOperationV07{
+		Sender:                        common.HexToAddress("0x1306b01bC3e4AD202612D3843387e94737673F53"),
+		Nonce:                         big.NewInt(8942),
+		Factory:                       &factory,
+		FactoryData:                   hexutil.MustDecode("0x5fbfb9cf"),
+		CallData:                      hexutil.MustDecode("0xb61d27f6000000000000000000000000"),
+		CallGasLimit:                  big.NewInt(35000),
+		VerificationGasLimit:          big.NewInt(70000),
+		PreVerificationGas:            big.NewInt(21000),
+		MaxFeePerGas:                  big.NewInt(3000000000),
+		MaxPriorityFeePerGas:          big.NewInt(1500000000),
+		Paymaster:                     &paymaster,
+		PaymasterVerificationGasLimit: big.NewInt(40000),
+		PaymasterPostOpGasLimit:       big.NewInt(15000),
+		PaymasterData:                 hexutil.MustDecode("0xdeadbeef"),
+		Signature:                     dummySignature,
+	}
+}
+
+// uint128 returns v as a 16-byte big-endian value.
+func uint128(v int64) []byte {
+	return common.LeftPadBytes(big.NewInt(v).Bytes(), 16)
+}
+
+func TestGetUserOpHashV07(t *testing.T) {
+	entryPoint := CanonicalEntryPointV07
+	chainID := big.NewInt(11155111)
+
+	full := testUserOpV07()
+	fullInitCode := append(full.Factory.Bytes(), full.FactoryData...)
+	fullPaymasterAndData := append(append(append(full.Paymaster.Bytes(), uint128(40000)...), uint128(15000)...), full.PaymasterData...)
+
+	tests := []struct {
+		name             string
+		op               *UserOperationV07
+		initCode         []byte
+		paymasterAndData []byte
+		want             common.Hash
+	}{
+		{
+			name:             "all fields",
+			op:               full,
+			initCode:         fullInitCode,
+			paymasterAndData: fullPaymasterAndData,
+			want:             common.HexToHash("0x5181a4a41379724390acaed6dbd0c86fa4d7a736f0ec940e3c064690e5603ee3"),
+		},
+		{
+			name: "empty op",
+			op:   &UserOperationV07{},
+			want: common.HexToHash("0xc2ae9ba70cf313be10ea729190547ac0dfa49cb6501877a84bec112f30781863"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetUserOpHashV07(tt.op, entryPoint, chainID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Rebuild the digest word by word, as UserOperationLib.encode and
+			// EntryPoint.getUserOpHash lay it out in v0.7.
+			op := tt.op
+			var packed []byte
+			for _, w := range [][]byte{
+				word(op.Sender.Bytes()),
+				word(orZero(op.Nonce).Bytes()),
+				crypto.Keccak256(tt.initCode),
+				crypto.Keccak256(op.CallData),
+				append(common.LeftPadBytes(orZero(op.VerificationGasLimit).Bytes(), 16), common.LeftPadBytes(orZero(op.CallGasLimit).Bytes(), 16)...),
+				word(orZero(op.PreVerificationGas).Bytes()),
+				append(common.LeftPadBytes(orZero(op.MaxPriorityFeePerGas).Bytes(), 16), common.LeftPadBytes(orZero(op.MaxFeePerGas).Bytes(), 16)...),
+				crypto.Keccak256(tt.paymasterAndData),
+			} {
+				packed = append(packed, w...)
+			}
+			manual := crypto.Keccak256Hash(crypto.Keccak256(packed), word(entryPoint.Bytes()), word(chainID.Bytes()))
+
+			if got != manual {
+				t.Errorf("GetUserOpHashV07 = %s, manual encoding gives %s", got, manual)
+			}
+			if got != tt.want {
+				t.Errorf("GetUserOpHashV07 = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserOperationToV07RoundTrip(t *testing.T) {
+	want := testUserOpV07()
+
+	packed, err := want.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op := &UserOperation{
+		Sender:               want.Sender,
+		Nonce:                want.Nonce,
+		InitCode:             packed.InitCode,
+		CallData:             want.CallData,
+		CallGasLimit:         want.CallGasLimit,
+		VerificationGasLimit: want.VerificationGasLimit,
+		PreVerificationGas:   want.PreVerificationGas,
+		MaxFeePerGas:         want.MaxFeePerGas,
+		MaxPriorityFeePerGas: want.MaxPriorityFeePerGas,
+		PaymasterAndData:     packed.PaymasterAndData,
+		Signature:            want.Signature,
+	}
+
+	got, err := op.ToV07()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantHash, err := GetUserOpHashV07(want, CanonicalEntryPointV07, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotHash, err := GetUserOpHashV07(got, CanonicalEntryPointV07, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotHash != wantHash {
+		t.Errorf("round trip changed the hash: %s != %s", gotHash, wantHash)
+	}
+}
+
+func TestPackUint128PairRejectsOverflow(t *testing.T) {
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 128)
+
+	if _, err := packUint128Pair(tooBig, nil); err == nil {
+		t.Error("expected an error for a 129-bit value")
+	}
+
+	if _, err := packUint128Pair(big.NewInt(-1), nil); err == nil {
+		t.Error("expected an error for a negative value")
+	}
+}
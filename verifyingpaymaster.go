@@ -0,0 +1,185 @@
+package goaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	paymaster "github.com/pavankpdev/goaa/gen"
+	"math/big"
+	"time"
+)
+
+var (
+	uint48Type, _ = abi.NewType("uint48", "", nil)
+
+	// verifyingPaymasterHashArgs mirrors the layout hashed by VerifyingPaymaster.getHash.
+	verifyingPaymasterHashArgs = abi.Arguments{
+		{Type: addressType},
+		{Type: uint256Type},
+		{Type: bytes32Type},
+		{Type: bytes32Type},
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: addressType},
+		{Type: uint256Type},
+		{Type: uint48Type},
+		{Type: uint48Type},
+	}
+
+	// verifyingPaymasterValidityArgs encodes the validity window placed after the paymaster address.
+	verifyingPaymasterValidityArgs = abi.Arguments{
+		{Type: uint48Type},
+		{Type: uint48Type},
+	}
+)
+
+// VerifyingPaymaster is a Paymaster for a self-hosted eth-infinitism v0.6 VerifyingPaymaster.
+// It signs sponsorships locally with the paymaster's verifying signer.
+type VerifyingPaymaster struct {
+	Address  common.Address                // The deployed VerifyingPaymaster contract
+	Signer   Signer                        // The paymaster's verifyingSigner
+	Contract *paymaster.VerifyingPaymaster // Binding used to read senderNonce
+	ValidFor time.Duration                 // How long a sponsorship stays valid, zero for no expiry
+}
+
+// NewVerifyingPaymaster binds the VerifyingPaymaster at address and checks that signer is
+// its verifyingSigner. ctx bounds the verifyingSigner call.
+func NewVerifyingPaymaster(ctx context.Context, address common.Address, signer Signer, backend bind.ContractBackend, validFor time.Duration) (*VerifyingPaymaster, error) {
+	contract, err := paymaster.NewVerifyingPaymaster(address, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	verifyingSigner, err := contract.VerifyingSigner(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+
+	if verifyingSigner != signer.Address() {
+		return nil, fmt.Errorf("paymaster %s expects verifying signer %s, got %s", address, verifyingSigner, signer.Address())
+	}
+
+	return &VerifyingPaymaster{
+		Address:  address,
+		Signer:   signer,
+		Contract: contract,
+		ValidFor: validFor,
+	}, nil
+}
+
+// forEntryPoint rejects EntryPoint v0.7, whose VerifyingPaymaster hashes a different layout.
+func (p *VerifyingPaymaster) forEntryPoint(version EntryPointVersion) (Paymaster, error) {
+	if version == EntryPointV07 {
+		return nil, errors.New("VerifyingPaymaster targets the EntryPoint v0.6 contract and cannot sponsor v0.7 userops")
+	}
+
+	return p, nil
+}
+
+// GetVerifyingPaymasterHash computes VerifyingPaymaster.getHash locally for op. senderNonce
+// is the paymaster's senderNonce for op.Sender.
+func GetVerifyingPaymasterHash(op *UserOperation, chainID *big.Int, paymasterAddress common.Address, senderNonce *big.Int, validUntil uint64, validAfter uint64) (common.Hash, error) {
+	encoded, err := verifyingPaymasterHashArgs.Pack(
+		op.Sender,
+		orZero(op.Nonce),
+		crypto.Keccak256Hash(op.InitCode),
+		crypto.Keccak256Hash(op.CallData),
+		orZero(op.CallGasLimit),
+		orZero(op.VerificationGasLimit),
+		orZero(op.PreVerificationGas),
+		orZero(op.MaxFeePerGas),
+		orZero(op.MaxPriorityFeePerGas),
+		chainID,
+		paymasterAddress,
+		orZero(senderNonce),
+		new(big.Int).SetUint64(validUntil),
+		new(big.Int).SetUint64(validAfter),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// GetPaymasterStubData implements Paymaster with a correctly sized dummy signature.
+func (p *VerifyingPaymaster) GetPaymasterStubData(_ context.Context, _ *UserOperation, _ common.Address, _ *big.Int) (*PaymasterData, error) {
+	validUntil, validAfter := p.validity()
+
+	paymasterAndData, err := p.pack(validUntil, validAfter, dummySignature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaymasterData{PaymasterAndData: paymasterAndData}, nil
+}
+
+// GetPaymasterData implements Paymaster by signing the paymaster hash of op.
+func (p *VerifyingPaymaster) GetPaymasterData(ctx context.Context, op *UserOperation, _ common.Address, chainID *big.Int) (*PaymasterData, error) {
+	senderNonce, err := p.Contract.SenderNonce(&bind.CallOpts{Context: ctx}, op.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	validUntil, validAfter := p.validity()
+
+	hash, err := GetVerifyingPaymasterHash(op, chainID, p.Address, senderNonce, validUntil, validAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signHash(ctx, p.Signer, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the paymaster hash: %w", err)
+	}
+
+	paymasterAndData, err := p.pack(validUntil, validAfter, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaymasterData{PaymasterAndData: paymasterAndData}, nil
+}
+
+// validity returns the validUntil and validAfter timestamps of a new sponsorship.
+func (p *VerifyingPaymaster) validity() (uint64, uint64) {
+	if p.ValidFor <= 0 {
+		return 0, 0
+	}
+
+	now := time.Now()
+
+	return uint64(now.Add(p.ValidFor).Unix()), uint64(now.Unix())
+}
+
+// pack builds paymasterAndData as the paymaster address, the ABI-encoded validity window
+// and the signature.
+func (p *VerifyingPaymaster) pack(validUntil uint64, validAfter uint64, signature []byte) ([]byte, error) {
+	validity, err := verifyingPaymasterValidityArgs.Pack(new(big.Int).SetUint64(validUntil), new(big.Int).SetUint64(validAfter))
+	if err != nil {
+		return nil, err
+	}
+
+	paymasterAndData := append(p.Address.Bytes(), validity...)
+
+	return append(paymasterAndData, signature...), nil
+}
+
+// GetPaymasterDeposit returns the paymaster's deposit at the EntryPoint, which funds the
+// userops it sponsors.
+func (sap *SmartAccountProvider) GetPaymasterDeposit(paymasterAddress common.Address) (*big.Int, error) {
+	return sap.GetPaymasterDepositContext(context.Background(), paymasterAddress)
+}
+
+// GetPaymasterDepositContext is GetPaymasterDeposit with a context for the EntryPoint call.
+func (sap *SmartAccountProvider) GetPaymasterDepositContext(ctx context.Context, paymasterAddress common.Address) (*big.Int, error) {
+	return sap.EntryPoint.BalanceOf(&bind.CallOpts{Context: ctx}, paymasterAddress)
+}
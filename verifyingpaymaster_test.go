@@ -0,0 +1,144 @@
+package goaa
+
+import (
+	"bytes"
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"testing"
+)
+
+func TestGetVerifyingPaymasterHash(t *testing.T) {
+	paymasterAddress := common.HexToAddress("0xe93eca6595fe94091dc1af46aac2a8b5d7990770")
+
+	tests := []struct {
+		name        string
+		op          *UserOperation
+		chainID     *big.Int
+		senderNonce *big.Int
+		validUntil  uint64
+		validAfter  uint64
+		want        common.Hash
+	}{
+		{
+			name:        "all fields",
+			op:          testUserOp(),
+			chainID:     big.NewInt(11155111),
+			senderNonce: big.NewInt(7),
+			validUntil:  1735689600,
+			validAfter:  1704067200,
+			want:        common.HexToHash("0xb76abbf573ddc69f6e77b74a265aaee2252ef2ff0c9edf32a7ec073fca4369b4"),
+		},
+		{
+			name:        "no expiry",
+			op:          testUserOp(),
+			chainID:     big.NewInt(137),
+			senderNonce: big.NewInt(0),
+			want:        common.HexToHash("0xb2f0e9676d4d4f78021521bd8f0358f8a0a6b7387e30db95ace1fed0f9ded322"),
+		},
+		{
+			name:        "largest uint48 window",
+			op:          &UserOperation{Sender: common.HexToAddress("0x94f3178AcB40d0E9c6967108e3711CF047D3240A")},
+			chainID:     big.NewInt(1),
+			senderNonce: big.NewInt(1),
+			validUntil:  1<<48 - 1,
+			validAfter:  1<<48 - 2,
+			want:        common.HexToHash("0xd038c5790463966fc1c7bd1831659efaa68256d6ea13df07791277483234f841"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetVerifyingPaymasterHash(tt.op, tt.chainID, paymasterAddress, tt.senderNonce, tt.validUntil, tt.validAfter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Rebuild the digest word by word, as VerifyingPaymaster.getHash abi.encodes it.
+			op := tt.op
+			var encoded []byte
+			for _, w := range [][]byte{
+				word(op.Sender.Bytes()),
+				word(orZero(op.Nonce).Bytes()),
+				crypto.Keccak256(op.InitCode),
+				crypto.Keccak256(op.CallData),
+				word(orZero(op.CallGasLimit).Bytes()),
+				word(orZero(op.VerificationGasLimit).Bytes()),
+				word(orZero(op.PreVerificationGas).Bytes()),
+				word(orZero(op.MaxFeePerGas).Bytes()),
+				word(orZero(op.MaxPriorityFeePerGas).Bytes()),
+				word(tt.chainID.Bytes()),
+				word(paymasterAddress.Bytes()),
+				word(tt.senderNonce.Bytes()),
+				word(new(big.Int).SetUint64(tt.validUntil).Bytes()),
+				word(new(big.Int).SetUint64(tt.validAfter).Bytes()),
+			} {
+				encoded = append(encoded, w...)
+			}
+			manual := crypto.Keccak256Hash(encoded)
+
+			if got != manual {
+				t.Errorf("GetVerifyingPaymasterHash = %s, manual encoding gives %s", got, manual)
+			}
+			if got != tt.want {
+				t.Errorf("GetVerifyingPaymasterHash = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyingPaymasterHashIgnoresPaymasterFields(t *testing.T) {
+	paymasterAddress := common.HexToAddress("0xe93eca6595fe94091dc1af46aac2a8b5d7990770")
+	op := testUserOp()
+
+	want, err := GetVerifyingPaymasterHash(op, big.NewInt(1), paymasterAddress, big.NewInt(0), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// getHash cannot cover paymasterAndData, which holds the signature, nor the op signature.
+	op.PaymasterAndData = []byte{0x01}
+	op.Signature = []byte{0x02}
+	got, err := GetVerifyingPaymasterHash(op, big.NewInt(1), paymasterAddress, big.NewInt(0), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Errorf("hash changed with paymasterAndData or the signature: %s != %s", got, want)
+	}
+}
+
+func TestVerifyingPaymasterPack(t *testing.T) {
+	p := &VerifyingPaymaster{Address: common.HexToAddress("0xe93eca6595fe94091dc1af46aac2a8b5d7990770")}
+	signature := hexutil.MustDecode("0x11223344")
+
+	paymasterAndData, err := p.pack(1735689600, 1704067200, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := common.BytesToAddress(paymasterAndData[:20]); got != p.Address {
+		t.Errorf("paymasterAndData[:20] = %s, want %s", got, p.Address)
+	}
+
+	// parsePaymasterAndData abi.decodes [20:84] as (uint48 validUntil, uint48 validAfter).
+	validity := append(word(big.NewInt(1735689600).Bytes()), word(big.NewInt(1704067200).Bytes())...)
+	if !bytes.Equal(paymasterAndData[20:84], validity) {
+		t.Errorf("paymasterAndData[20:84] = %x, want %x", paymasterAndData[20:84], validity)
+	}
+
+	if !bytes.Equal(paymasterAndData[84:], signature) {
+		t.Errorf("paymasterAndData[84:] = %x, want %x", paymasterAndData[84:], signature)
+	}
+
+	stub, err := p.GetPaymasterStubData(context.Background(), testUserOp(), CanonicalEntryPointV06, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stub.PaymasterAndData) != 84+len(dummySignature) {
+		t.Errorf("stub paymasterAndData is %d bytes, want %d", len(stub.PaymasterAndData), 84+len(dummySignature))
+	}
+}